@@ -0,0 +1,247 @@
+package gropher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GraphDigest is a content-addressed summary of a Graph: a stable SHA-256
+// digest per node and per edge, plus a Merkle Root aggregating all of
+// them. Two Graphs with equal Digests are considered to hold the same
+// data, which lets Diff/ApplyDiff transmit only what changed.
+type GraphDigest struct {
+	Root string
+	// Nodes maps a node id to its digest.
+	Nodes map[string]string
+	// Edges maps an edge identity ("from|to|key") to its digest.
+	Edges map[string]string
+}
+
+// Digest computes the graph's GraphDigest.
+func (g *Graph) Digest() GraphDigest {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.digestLocked()
+}
+
+// digestLocked computes the graph's GraphDigest. Callers must hold at
+// least a read lock on g.
+func (g *Graph) digestLocked() GraphDigest {
+	d := GraphDigest{
+		Nodes: make(map[string]string, len(g.Nodes)),
+		Edges: make(map[string]string),
+	}
+
+	for id, node := range g.Nodes {
+		d.Nodes[id] = nodeDigest(node)
+	}
+
+	for from, edges := range g.Edges {
+		for to, parallel := range edges {
+			for _, edge := range parallel {
+				d.Edges[edgeIdentity(from, to, edge.Key)] = edgeDigest(edge)
+			}
+		}
+	}
+
+	d.Root = merkleRoot(d.Nodes, d.Edges)
+	return d
+}
+
+// GraphDiff describes how to turn one Graph into another: the Nodes/Edges
+// present in the new Graph but not the old, present in both but changed,
+// and present in the old but not the new.
+type GraphDiff struct {
+	AddedNodes   []*Node
+	RemovedNodes []string
+	ChangedNodes []*Node
+
+	AddedEdges   []*Edge
+	RemovedEdges []*Edge
+	ChangedEdges []*Edge
+}
+
+// Diff compares a and b by content digest and returns the GraphDiff that
+// turns a into b.
+func Diff(a, b *Graph) GraphDiff {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var diff GraphDiff
+
+	for id, bNode := range b.Nodes {
+		aNode, existed := a.Nodes[id]
+		switch {
+		case !existed:
+			diff.AddedNodes = append(diff.AddedNodes, bNode)
+		case nodeDigest(aNode) != nodeDigest(bNode):
+			diff.ChangedNodes = append(diff.ChangedNodes, bNode)
+		}
+	}
+	for id := range a.Nodes {
+		if _, exists := b.Nodes[id]; !exists {
+			diff.RemovedNodes = append(diff.RemovedNodes, id)
+		}
+	}
+
+	aEdges := indexEdgesByIdentity(a.Edges)
+	bEdges := indexEdgesByIdentity(b.Edges)
+
+	for identity, bEdge := range bEdges {
+		aEdge, existed := aEdges[identity]
+		switch {
+		case !existed:
+			diff.AddedEdges = append(diff.AddedEdges, bEdge)
+		case edgeDigest(aEdge) != edgeDigest(bEdge):
+			diff.ChangedEdges = append(diff.ChangedEdges, bEdge)
+		}
+	}
+	for identity, aEdge := range aEdges {
+		if _, exists := bEdges[identity]; !exists {
+			diff.RemovedEdges = append(diff.RemovedEdges, aEdge)
+		}
+	}
+
+	return diff
+}
+
+// ApplyDiff mutates g to apply d, as produced by Diff(old, new) run
+// against a copy of old. Nodes are removed, then added, then changed, and
+// edges are removed and then added/changed, so that an edge referencing a
+// node added by the same diff always finds its endpoints in place.
+func (g *Graph) ApplyDiff(d GraphDiff) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, id := range d.RemovedNodes {
+		if _, exists := g.Nodes[id]; exists {
+			g.removeNodeLocked(id)
+		}
+	}
+
+	for _, node := range d.AddedNodes {
+		g.Nodes[node.ID] = node
+		if g.Edges[node.ID] == nil {
+			g.Edges[node.ID] = make(map[string][]*Edge)
+		}
+		g.indexNodeType(node)
+	}
+
+	for _, node := range d.ChangedNodes {
+		if old, exists := g.Nodes[node.ID]; exists {
+			g.unindexNodeType(old)
+		}
+		g.Nodes[node.ID] = node
+		g.indexNodeType(node)
+	}
+
+	for _, edge := range d.RemovedEdges {
+		// Already-absent edges (e.g. their source node was removed above)
+		// are not an error: the diff's intent is satisfied either way.
+		_ = g.removeEdgeLocked(edge.From, edge.To, edge.Key)
+	}
+
+	for _, edge := range d.AddedEdges {
+		if err := g.addEdgeLocked(edge); err != nil {
+			return fmt.Errorf("failed to apply added edge %s->%s: %v", edge.From, edge.To, err)
+		}
+	}
+	for _, edge := range d.ChangedEdges {
+		if err := g.addEdgeLocked(edge); err != nil {
+			return fmt.Errorf("failed to apply changed edge %s->%s: %v", edge.From, edge.To, err)
+		}
+	}
+
+	return nil
+}
+
+// edgeIdentity is the key used to match the "same" edge across two graphs
+// for diffing: its endpoints and key, but not its weight or data.
+func edgeIdentity(from, to, key string) string {
+	return from + "|" + to + "|" + key
+}
+
+// indexEdgesByIdentity flattens a Graph's Edges into identity -> *Edge.
+func indexEdgesByIdentity(edges map[string]map[string][]*Edge) map[string]*Edge {
+	index := make(map[string]*Edge)
+	for from, tos := range edges {
+		for to, parallel := range tos {
+			for _, edge := range parallel {
+				index[edgeIdentity(from, to, edge.Key)] = edge
+			}
+		}
+	}
+	return index
+}
+
+// nodeDigest hashes a node's id and the canonical JSON form of its Data.
+func nodeDigest(node *Node) string {
+	canon, err := canonicalJSON(node.Data)
+	if err != nil {
+		// Data isn't JSON-marshalable (e.g. a func or channel); fall back
+		// to a %v representation so Digest still produces a stable value.
+		canon = []byte(fmt.Sprintf("%v", node.Data))
+	}
+
+	h := sha256.New()
+	h.Write([]byte(node.ID))
+	h.Write([]byte{'|'})
+	h.Write(canon)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// edgeDigest hashes an edge's identity and weight.
+func edgeDigest(edge *Edge) string {
+	payload := strings.Join([]string{
+		edge.From, edge.To, edge.Key,
+		strconv.FormatFloat(edge.Weight, 'f', -1, 64),
+	}, "|")
+	h := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(h[:])
+}
+
+// merkleRoot aggregates sorted node/edge digests into a single top-level
+// hash, so any change to any node or edge changes the root.
+func merkleRoot(nodes, edges map[string]string) string {
+	hashes := make([]string, 0, len(nodes)+len(edges))
+	for _, h := range nodes {
+		hashes = append(hashes, h)
+	}
+	for _, h := range edges {
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+
+	h := sha256.New()
+	for _, hash := range hashes {
+		h.Write([]byte(hash))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalJSON renders v as JSON with map keys sorted (encoding/json's
+// default for map[string]interface{}) and numeric/struct representations
+// normalized, so semantically equal payloads hash equal regardless of
+// their original Go type or field order. It round-trips v through
+// interface{} to erase struct field order before the final marshal.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(generic)
+}
@@ -0,0 +1,116 @@
+package gropher
+
+import "testing"
+
+func buildDigestGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := New()
+	for i := 0; i < 20; i++ {
+		id := string(rune('a' + i))
+		if err := g.AddTypedNode(id, "letter", map[string]interface{}{"n": float64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 19; i++ {
+		from := string(rune('a' + i))
+		to := string(rune('a' + i + 1))
+		if err := g.AddEdge(from, to, float64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A couple of parallel edges to exercise the Key dimension.
+	if err := g.AddEdgeKeyed("a", "b", "alt", 99, nil); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestDigestStableForEqualGraphs(t *testing.T) {
+	a := buildDigestGraph(t)
+	b := buildDigestGraph(t)
+
+	if a.Digest().Root != b.Digest().Root {
+		t.Error("Expected two independently built but equal graphs to share a Digest Root")
+	}
+}
+
+func TestDigestChangesWithContent(t *testing.T) {
+	g := buildDigestGraph(t)
+	before := g.Digest().Root
+
+	if err := g.AddEdge("a", "c", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if after := g.Digest().Root; after == before {
+		t.Error("Expected Digest Root to change after adding an edge")
+	}
+}
+
+func TestDiffApplyDiffRoundTrip(t *testing.T) {
+	oldGraph := buildDigestGraph(t)
+
+	newGraph := buildDigestGraph(t)
+	// Remove a node (and its Edges).
+	if err := newGraph.RemoveNode("s"); err != nil {
+		t.Fatal(err)
+	}
+	// Add a new node and edge.
+	if err := newGraph.AddTypedNode("z", "letter", map[string]interface{}{"n": float64(99)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := newGraph.AddEdge("r", "z", 42); err != nil {
+		t.Fatal(err)
+	}
+	// Change an existing node's Data.
+	changed, err := newGraph.GetNode("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed.Data = map[string]interface{}{"n": float64(1000)}
+	// Change an existing edge's weight.
+	if err := newGraph.AddEdge("b", "c", 12345); err != nil {
+		t.Fatal(err)
+	}
+	// Remove the parallel keyed edge.
+	if err := newGraph.RemoveEdgeKeyed("a", "b", "alt"); err != nil {
+		t.Fatal(err)
+	}
+
+	diff := Diff(oldGraph, newGraph)
+
+	replica := buildDigestGraph(t)
+	if err := replica.ApplyDiff(diff); err != nil {
+		t.Fatalf("ApplyDiff returned error: %v", err)
+	}
+
+	want := newGraph.Digest().Root
+	got := replica.Digest().Root
+	if got != want {
+		t.Errorf("Expected ApplyDiff(Diff(old, new)) Digest to match new's Digest\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	a := buildDigestGraph(t)
+	b := buildDigestGraph(t)
+
+	diff := Diff(a, b)
+	if len(diff.AddedNodes) != 0 || len(diff.RemovedNodes) != 0 || len(diff.ChangedNodes) != 0 ||
+		len(diff.AddedEdges) != 0 || len(diff.RemovedEdges) != 0 || len(diff.ChangedEdges) != 0 {
+		t.Errorf("Expected empty diff for equal graphs, got %+v", diff)
+	}
+}
+
+func TestCanonicalJSONNormalizesNumbers(t *testing.T) {
+	intForm, err := canonicalJSON(map[string]interface{}{"n": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	floatForm, err := canonicalJSON(map[string]interface{}{"n": 1.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(intForm) != string(floatForm) {
+		t.Errorf("Expected canonicalJSON to normalize 1 and 1.0 equally, got %q vs %q", intForm, floatForm)
+	}
+}
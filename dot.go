@@ -0,0 +1,237 @@
+package gropher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DOTOptions controls how WriteDOT/SaveDOT render a Graph as Graphviz DOT.
+type DOTOptions struct {
+	// Directed selects between "digraph" and "graph" output.
+	// DefaultDOTOptions sets it to true, matching Graph's directed edge
+	// semantics; the zero value selects undirected "graph" output.
+	Directed bool
+	// NodeAttrFunc, if set, supplies extra Graphviz attributes (e.g.
+	// color, shape) for a node, merged over the default "label" attribute.
+	NodeAttrFunc func(*Node) map[string]string
+	// EdgeAttrFunc, if set, supplies extra Graphviz attributes for an
+	// edge, merged over the default "label"/"weight" attributes.
+	EdgeAttrFunc func(*Edge) map[string]string
+	// RankDir sets the graph's rankdir attribute (e.g. "LR", "TB") when
+	// non-empty.
+	RankDir string
+	// SubgraphFunc, if set, assigns each node to a named subgraph. Nodes
+	// for which it returns the same non-empty name are grouped into a DOT
+	// "subgraph cluster_<name> { ... }" block; nodes for which it returns
+	// "" are emitted at the top level as usual.
+	SubgraphFunc func(*Node) string
+}
+
+// DefaultDOTOptions returns the options WriteDOT/SaveDOT use when none are
+// supplied: a directed graph with no extra attributes.
+func DefaultDOTOptions() DOTOptions {
+	return DOTOptions{Directed: true}
+}
+
+// SaveDOT writes the graph to filename in Graphviz DOT format using the
+// default options, suitable for piping into `dot -Tsvg`.
+func (g *Graph) SaveDOT(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer file.Close()
+
+	return g.WriteDOT(file)
+}
+
+// WriteDOT writes the graph to w in Graphviz DOT format using the default
+// options.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	return g.WriteDOTWithOptions(w, DefaultDOTOptions())
+}
+
+// WriteDOTWithOptions writes the graph to w in Graphviz DOT format using
+// opts to control direction, rank direction, and per-node/per-edge
+// attributes.
+func (g *Graph) WriteDOTWithOptions(w io.Writer, opts DOTOptions) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	keyword := "digraph"
+	edgeOp := "->"
+	if !opts.Directed {
+		keyword = "graph"
+		edgeOp = "--"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s G {\n", keyword)
+	if opts.RankDir != "" {
+		fmt.Fprintf(&b, "  rankdir=%s;\n", opts.RankDir)
+	}
+
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var subgraphNames []string
+	subgraphLines := make(map[string][]string)
+
+	for _, id := range ids {
+		node := g.Nodes[id]
+		attrs := map[string]string{"label": dotLabel(node.Data)}
+		if opts.NodeAttrFunc != nil {
+			for k, v := range opts.NodeAttrFunc(node) {
+				attrs[k] = v
+			}
+		}
+		line := fmt.Sprintf("%s [%s];\n", dotQuote(id), dotAttrString(attrs))
+
+		name := ""
+		if opts.SubgraphFunc != nil {
+			name = opts.SubgraphFunc(node)
+		}
+		if name == "" {
+			fmt.Fprintf(&b, "  %s", line)
+			continue
+		}
+		if _, seen := subgraphLines[name]; !seen {
+			subgraphNames = append(subgraphNames, name)
+		}
+		subgraphLines[name] = append(subgraphLines[name], line)
+	}
+
+	sort.Strings(subgraphNames)
+	for _, name := range subgraphNames {
+		fmt.Fprintf(&b, "  subgraph %s {\n", dotQuote("cluster_"+name))
+		for _, line := range subgraphLines[name] {
+			fmt.Fprintf(&b, "    %s", line)
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, from := range ids {
+		tos := make([]string, 0, len(g.Edges[from]))
+		for to := range g.Edges[from] {
+			tos = append(tos, to)
+		}
+		sort.Strings(tos)
+
+		for _, to := range tos {
+			for _, edge := range g.Edges[from][to] {
+				parts := []string{
+					fmt.Sprintf("label=%s", dotQuote(strconv.FormatFloat(edge.Weight, 'f', 2, 64))),
+					fmt.Sprintf("weight=%s", strconv.FormatFloat(edge.Weight, 'f', -1, 64)),
+				}
+				if opts.EdgeAttrFunc != nil {
+					extra := opts.EdgeAttrFunc(edge)
+					keys := make([]string, 0, len(extra))
+					for k := range extra {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+					for _, k := range keys {
+						parts = append(parts, fmt.Sprintf("%s=%s", k, dotQuote(extra[k])))
+					}
+				}
+
+				fmt.Fprintf(&b, "  %s %s %s [%s];\n", dotQuote(from), edgeOp, dotQuote(to), strings.Join(parts, ", "))
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write DOT: %v", err)
+	}
+	return nil
+}
+
+// dotLabel renders a node payload as a DOT label: scalars are stringified
+// directly, maps/structs are rendered as a compact "key=value\n..." label.
+func dotLabel(data interface{}) string {
+	if data == nil {
+		return ""
+	}
+
+	switch data.(type) {
+	case string, bool,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return fmt.Sprintf("%v", data)
+	}
+
+	fields, err := dotFields(data)
+	if err != nil || len(fields) == 0 {
+		return fmt.Sprintf("%v", data)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dotFields flattens a map or struct payload to a string-keyed map for
+// label rendering, going through JSON so struct field names/tags resolve
+// the same way they do elsewhere in this package.
+func dotFields(data interface{}) (map[string]interface{}, error) {
+	if m, ok := data.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Struct && !(v.Kind() == reflect.Map) {
+		return nil, fmt.Errorf("not a map or struct")
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// dotQuote double-quotes an identifier and escapes embedded quotes, as
+// required for arbitrary DOT node ids.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// dotAttrString renders an attribute map as "k1=\"v1\", k2=\"v2\"" in
+// stable (sorted) key order.
+func dotAttrString(attrs map[string]string) string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, dotQuote(attrs[k])))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,84 @@
+package gropher
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	g := New()
+	if err := g.AddNode("a", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddNode("b", map[string]interface{}{"color": "red"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge("a", "b", 1.5); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT returned error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"digraph G {",
+		`"a" [label="hello"];`,
+		`"b" [label="color=red"];`,
+		`"a" -> "b" [label="1.50", weight=1.5];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected DOT output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteDOTWithOptions(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+	g.AddNode("b", nil)
+	g.AddEdge("a", "b", 1)
+
+	opts := DOTOptions{
+		Directed: false,
+		RankDir:  "LR",
+		NodeAttrFunc: func(n *Node) map[string]string {
+			return map[string]string{"shape": "box"}
+		},
+		EdgeAttrFunc: func(e *Edge) map[string]string {
+			return map[string]string{"color": "blue"}
+		},
+	}
+
+	var buf strings.Builder
+	if err := g.WriteDOTWithOptions(&buf, opts); err != nil {
+		t.Fatalf("WriteDOTWithOptions returned error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"graph G {",
+		"rankdir=LR;",
+		`shape="box"`,
+		`color="blue"`,
+		`"a" -- "b"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected DOT output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestSaveDOT(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+
+	filename := "test_graph.dot"
+	if err := g.SaveDOT(filename); err != nil {
+		t.Fatalf("SaveDOT returned error: %v", err)
+	}
+	defer os.Remove(filename)
+}
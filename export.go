@@ -0,0 +1,340 @@
+package gropher
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Format identifies one of the Graph's supported export/import encodings.
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatGraphML
+	FormatDOT
+	FormatCSV
+)
+
+// String returns the Format's lowercase name, as used e.g. in error messages.
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatGraphML:
+		return "graphml"
+	case FormatDOT:
+		return "dot"
+	case FormatCSV:
+		return "csv"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// Export writes the graph to w in format f, using that format's default
+// options (DefaultDOTOptions for FormatDOT). For custom DOT options, call
+// ExportDOT directly.
+func (g *Graph) Export(w io.Writer, f Format) error {
+	switch f {
+	case FormatJSON:
+		return g.ExportJSON(w)
+	case FormatGraphML:
+		return g.ExportGraphML(w)
+	case FormatDOT:
+		return g.ExportDOT(w, DefaultDOTOptions())
+	case FormatCSV:
+		return g.ExportCSV(w)
+	default:
+		return fmt.Errorf("gropher: unsupported export format %v", f)
+	}
+}
+
+// ExportGraphML writes the graph to w in GraphML format. It is an alias for
+// WriteGraphML, named to match the other Export* functions.
+func (g *Graph) ExportGraphML(w io.Writer) error {
+	return g.WriteGraphML(w)
+}
+
+// ImportGraphML replaces the graph's contents with the GraphML document
+// read from r. It is an alias for ReadGraphML.
+func (g *Graph) ImportGraphML(r io.Reader) error {
+	return g.ReadGraphML(r)
+}
+
+// ExportDOT writes the graph to w in Graphviz DOT format using opts. It is
+// an alias for WriteDOTWithOptions.
+func (g *Graph) ExportDOT(w io.Writer, opts DOTOptions) error {
+	return g.WriteDOTWithOptions(w, opts)
+}
+
+// ExportCSV writes the graph's edges to w as a CSV edge list with header
+// "from,to,key,kind,weight", one row per edge (including parallel edges).
+// Node Data and Type are not preserved; for a full round trip including
+// those, use ExportJSON or ExportGraphML.
+func (g *Graph) ExportCSV(w io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"from", "to", "key", "kind", "weight"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %v", err)
+	}
+
+	froms := make([]string, 0, len(g.Edges))
+	for from := range g.Edges {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+
+	for _, from := range froms {
+		tos := make([]string, 0, len(g.Edges[from]))
+		for to := range g.Edges[from] {
+			tos = append(tos, to)
+		}
+		sort.Strings(tos)
+
+		for _, to := range tos {
+			for _, edge := range g.Edges[from][to] {
+				row := []string{from, to, edge.Key, edge.Kind, strconv.FormatFloat(edge.Weight, 'f', -1, 64)}
+				if err := writer.Write(row); err != nil {
+					return fmt.Errorf("failed to write CSV row: %v", err)
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV: %v", err)
+	}
+	return nil
+}
+
+// ImportCSV replaces the graph's contents with the edge list read from r in
+// the format written by ExportCSV. A node implied by an edge's endpoint
+// that wasn't otherwise declared is created with nil Data.
+func (g *Graph) ImportCSV(r io.Reader) error {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV: %v", err)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("malformed CSV: missing header row")
+	}
+
+	col := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		col[name] = i
+	}
+	for _, required := range []string{"from", "to", "weight"} {
+		if _, ok := col[required]; !ok {
+			return fmt.Errorf("malformed CSV: missing %q column", required)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Nodes = make(map[string]*Node)
+	g.Edges = make(map[string]map[string][]*Edge)
+	g.typeIndex = make(map[string]map[string]*Node)
+
+	ensureNode := func(id string) {
+		if _, exists := g.Nodes[id]; exists {
+			return
+		}
+		node := &Node{ID: id}
+		g.Nodes[id] = node
+		g.Edges[id] = make(map[string][]*Edge)
+		g.indexNodeType(node)
+	}
+
+	for i, row := range records[1:] {
+		rowNum := i + 2 // +1 for 1-indexing, +1 for the header row
+		from := row[col["from"]]
+		to := row[col["to"]]
+
+		weight, err := strconv.ParseFloat(row[col["weight"]], 64)
+		if err != nil {
+			return fmt.Errorf("malformed CSV: row %d has non-numeric weight %q", rowNum, row[col["weight"]])
+		}
+
+		ensureNode(from)
+		ensureNode(to)
+
+		edge := &Edge{From: from, To: to, Weight: weight}
+		if idx, ok := col["key"]; ok {
+			edge.Key = row[idx]
+		}
+		if idx, ok := col["kind"]; ok {
+			edge.Kind = row[idx]
+		}
+		if err := g.addEdgeLocked(edge); err != nil {
+			return fmt.Errorf("malformed CSV: row %d: %v", rowNum, err)
+		}
+	}
+
+	return nil
+}
+
+// ExportJSON streams the graph to w as {"nodes":[...],"edges":[...]},
+// encoding one node or edge at a time via json.Encoder rather than building
+// the full in-memory graphData struct the way SaveToFile does, so exporting
+// a very large graph doesn't require holding a second copy of it in memory.
+func (g *Graph) ExportJSON(w io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	enc := json.NewEncoder(w)
+
+	if _, err := io.WriteString(w, `{"nodes":[`); err != nil {
+		return fmt.Errorf("failed to write JSON: %v", err)
+	}
+	first := true
+	for _, node := range g.Nodes {
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return fmt.Errorf("failed to write JSON: %v", err)
+			}
+		}
+		first = false
+		if err := enc.Encode(node); err != nil {
+			return fmt.Errorf("failed to encode node %s: %v", node.ID, err)
+		}
+	}
+
+	if _, err := io.WriteString(w, `],"edges":[`); err != nil {
+		return fmt.Errorf("failed to write JSON: %v", err)
+	}
+	first = true
+	for _, tos := range g.Edges {
+		for _, parallel := range tos {
+			for _, edge := range parallel {
+				if !first {
+					if _, err := io.WriteString(w, ","); err != nil {
+						return fmt.Errorf("failed to write JSON: %v", err)
+					}
+				}
+				first = false
+				if err := enc.Encode(edge); err != nil {
+					return fmt.Errorf("failed to encode edge %s->%s: %v", edge.From, edge.To, err)
+				}
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, "]}"); err != nil {
+		return fmt.Errorf("failed to write JSON: %v", err)
+	}
+	return nil
+}
+
+// ImportJSON replaces the graph's contents with the Nodes/Edges streamed
+// from r in the format written by ExportJSON, decoding one node or edge at
+// a time via json.Decoder's token stream rather than buffering the whole
+// payload into a single struct.
+func (g *Graph) ImportJSON(r io.Reader) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Nodes = make(map[string]*Node)
+	g.Edges = make(map[string]map[string][]*Edge)
+	g.typeIndex = make(map[string]map[string]*Node)
+
+	// Edges may be decoded before every node has been seen, so they are
+	// staged here and only wired up (via addEdgeLocked, which validates
+	// both endpoints) once the whole document has been read.
+	var pendingEdges []*Edge
+
+	for dec.More() {
+		key, err := expectJSONObjectKey(dec)
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "nodes":
+			if err := expectJSONDelim(dec, '['); err != nil {
+				return err
+			}
+			for dec.More() {
+				var node Node
+				if err := dec.Decode(&node); err != nil {
+					return fmt.Errorf("failed to decode node: %v", err)
+				}
+				g.Nodes[node.ID] = &node
+				g.Edges[node.ID] = make(map[string][]*Edge)
+				g.indexNodeType(&node)
+			}
+			if err := expectJSONDelim(dec, ']'); err != nil {
+				return err
+			}
+		case "edges":
+			if err := expectJSONDelim(dec, '['); err != nil {
+				return err
+			}
+			for dec.More() {
+				var edge Edge
+				if err := dec.Decode(&edge); err != nil {
+					return fmt.Errorf("failed to decode edge: %v", err)
+				}
+				pendingEdges = append(pendingEdges, &edge)
+			}
+			if err := expectJSONDelim(dec, ']'); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("malformed JSON: unexpected key %q", key)
+		}
+	}
+
+	if err := expectJSONDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	for _, edge := range pendingEdges {
+		if err := g.addEdgeLocked(edge); err != nil {
+			return fmt.Errorf("malformed JSON: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// expectJSONDelim reads the next token from dec and errors unless it is
+// exactly want.
+func expectJSONDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode JSON: %v", err)
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || delim != want {
+		return fmt.Errorf("malformed JSON: expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// expectJSONObjectKey reads the next token from dec and errors unless it is
+// a string, as required for an object key.
+func expectJSONObjectKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode JSON: %v", err)
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("malformed JSON: expected object key, got %v", tok)
+	}
+	return key, nil
+}
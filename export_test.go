@@ -0,0 +1,183 @@
+package gropher
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func buildExportGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := New()
+	if err := g.AddTypedNode("a", "letter", "A"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddNode("b", 2.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddNode("c", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedEdge("a", "b", "knows", 1.5, EdgeOptions{Key: "k1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge("b", "c", 2); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestExportImportJSONRoundTrip(t *testing.T) {
+	g := buildExportGraph(t)
+
+	var buf bytes.Buffer
+	if err := g.ExportJSON(&buf); err != nil {
+		t.Fatalf("ExportJSON returned error: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatalf("ExportJSON did not produce valid JSON: %v", err)
+	}
+	if _, ok := raw["nodes"]; !ok {
+		t.Error(`Expected top-level "nodes" key`)
+	}
+	if _, ok := raw["edges"]; !ok {
+		t.Error(`Expected top-level "edges" key`)
+	}
+
+	loaded := New()
+	if err := loaded.ImportJSON(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("ImportJSON returned error: %v", err)
+	}
+
+	if loaded.Digest().Root != g.Digest().Root {
+		t.Error("Expected ImportJSON(ExportJSON(g)) to reproduce g's Digest")
+	}
+}
+
+func TestImportJSONRejectsMalformedInput(t *testing.T) {
+	g := New()
+	if err := g.ImportJSON(strings.NewReader(`{"nodes": not-json`)); err == nil {
+		t.Error("Expected error for malformed JSON")
+	}
+	if err := g.ImportJSON(strings.NewReader(`{"bogus": []}`)); err == nil {
+		t.Error("Expected error for an unexpected top-level key")
+	}
+}
+
+func TestExportImportCSVRoundTrip(t *testing.T) {
+	g := buildExportGraph(t)
+
+	var buf bytes.Buffer
+	if err := g.ExportCSV(&buf); err != nil {
+		t.Fatalf("ExportCSV returned error: %v", err)
+	}
+
+	loaded := New()
+	if err := loaded.ImportCSV(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("ImportCSV returned error: %v", err)
+	}
+
+	edges, err := loaded.GetEdges("a", "b")
+	if err != nil {
+		t.Fatalf("Expected a->b edge to survive the round trip: %v", err)
+	}
+	if len(edges) != 1 || edges[0].Weight != 1.5 || edges[0].Kind != "knows" {
+		t.Errorf("Expected a->b edge with weight 1.5 and kind knows, got %+v", edges)
+	}
+
+	if _, err := loaded.GetNode("c"); err != nil {
+		t.Errorf("Expected node c to exist via edge endpoint inference: %v", err)
+	}
+}
+
+func TestImportCSVRejectsMissingColumns(t *testing.T) {
+	g := New()
+	if err := g.ImportCSV(strings.NewReader("from,to\na,b\n")); err == nil {
+		t.Error("Expected error for CSV missing the weight column")
+	}
+}
+
+func TestExportDispatcher(t *testing.T) {
+	g := buildExportGraph(t)
+
+	for _, f := range []Format{FormatJSON, FormatGraphML, FormatDOT, FormatCSV} {
+		var buf bytes.Buffer
+		if err := g.Export(&buf, f); err != nil {
+			t.Errorf("Export(%v) returned error: %v", f, err)
+		}
+		if buf.Len() == 0 {
+			t.Errorf("Export(%v) produced no output", f)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := g.Export(&buf, Format(99)); err == nil {
+		t.Error("Expected error for unsupported Format")
+	}
+}
+
+func TestExportGraphMLAndDOTAliases(t *testing.T) {
+	g := buildExportGraph(t)
+
+	var gmlBuf bytes.Buffer
+	if err := g.ExportGraphML(&gmlBuf); err != nil {
+		t.Fatalf("ExportGraphML returned error: %v", err)
+	}
+	loaded := New()
+	if err := loaded.ImportGraphML(bytes.NewReader(gmlBuf.Bytes())); err != nil {
+		t.Fatalf("ImportGraphML returned error: %v", err)
+	}
+	if _, err := loaded.GetNode("a"); err != nil {
+		t.Errorf("Expected node a after ImportGraphML: %v", err)
+	}
+
+	var dotBuf bytes.Buffer
+	if err := g.ExportDOT(&dotBuf, DOTOptions{Directed: true}); err != nil {
+		t.Fatalf("ExportDOT returned error: %v", err)
+	}
+	if !strings.Contains(dotBuf.String(), "digraph") {
+		t.Error("Expected ExportDOT output to contain 'digraph'")
+	}
+}
+
+func TestDOTSubgraphGrouping(t *testing.T) {
+	g := buildExportGraph(t)
+
+	opts := DefaultDOTOptions()
+	opts.SubgraphFunc = func(n *Node) string {
+		if n.Type == "letter" {
+			return "letters"
+		}
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := g.ExportDOT(&buf, opts); err != nil {
+		t.Fatalf("ExportDOT returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `subgraph "cluster_letters"`) {
+		t.Errorf("Expected a cluster_letters subgraph block, got:\n%s", out)
+	}
+	if !strings.Contains(out, `"a" [`) {
+		t.Errorf("Expected node a to still be rendered, got:\n%s", out)
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	cases := map[Format]string{
+		FormatJSON:    "json",
+		FormatGraphML: "graphml",
+		FormatDOT:     "dot",
+		FormatCSV:     "csv",
+	}
+	for f, want := range cases {
+		if got := f.String(); got != want {
+			t.Errorf("Format(%d).String() = %q, want %q", int(f), got, want)
+		}
+	}
+}
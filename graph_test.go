@@ -458,7 +458,7 @@ func TestGraphSerialization(t *testing.T) {
 
 		// Verify node count
 		nodeCount := 0
-		for range newGraph.nodes {
+		for range newGraph.Nodes {
 			nodeCount++
 		}
 		if nodeCount != 1000 {
@@ -0,0 +1,298 @@
+package gropher
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// GraphML key ids used when writing Nodes/Edges. Readers must not assume
+// these ids on input: key declarations are always resolved by attr.name.
+const (
+	graphMLNodeDataKeyID     = "d0"
+	graphMLEdgeWeightKeyID   = "d1"
+	graphMLNodeDataKindKeyID = "d2"
+)
+
+// graphMLDataKind tags how a <data> element's text was encoded, so
+// graphMLDecodeValue doesn't have to guess from content alone: a node
+// whose Data is the string "42" must decode back to "42", not the number
+// 42, even though "42" also happens to parse as JSON.
+const (
+	graphMLDataKindString = "string"
+	graphMLDataKindJSON   = "json"
+)
+
+// graphMLDocument mirrors the subset of the GraphML XML schema
+// (http://graphml.graphdrawing.org/xmlns) that this package reads and
+// writes: <key> declarations followed by a single <graph>.
+type graphMLDocument struct {
+	XMLName xml.Name       `xml:"graphml"`
+	Xmlns   string         `xml:"xmlns,attr"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graph   graphMLGraphEl `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraphEl struct {
+	EdgeDefault string          `xml:"edgedefault,attr"`
+	Nodes       []graphMLNodeEl `xml:"node"`
+	Edges       []graphMLEdgeEl `xml:"edge"`
+}
+
+type graphMLNodeEl struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLDate `xml:"data"`
+}
+
+type graphMLEdgeEl struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLDate `xml:"data"`
+}
+
+// graphMLDate is a <data key="...">value</data> element. (Named "Date" to
+// avoid colliding with the encoding/json-flavored helpers below.)
+type graphMLDate struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// SaveGraphML writes the graph to filename in GraphML format, suitable for
+// NetworkX, yEd, Gephi, and igraph.
+func (g *Graph) SaveGraphML(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer file.Close()
+
+	if err := g.WriteGraphML(file); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WriteGraphML writes the graph to w in GraphML format.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	doc := graphMLDocument{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: graphMLNodeDataKeyID, For: "node", AttrName: "data", AttrType: "string"},
+			{ID: graphMLEdgeWeightKeyID, For: "edge", AttrName: "weight", AttrType: "double"},
+			{ID: graphMLNodeDataKindKeyID, For: "node", AttrName: "dataKind", AttrType: "string"},
+		},
+		Graph: graphMLGraphEl{EdgeDefault: "directed"},
+	}
+
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		node := g.Nodes[id]
+		nodeEl := graphMLNodeEl{ID: node.ID}
+		value, kind, err := graphMLEncodeValue(node.Data)
+		if err != nil {
+			return fmt.Errorf("failed to encode data for node %s: %v", node.ID, err)
+		}
+		if kind != "" {
+			nodeEl.Data = append(nodeEl.Data,
+				graphMLDate{Key: graphMLNodeDataKeyID, Value: value},
+				graphMLDate{Key: graphMLNodeDataKindKeyID, Value: kind})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, nodeEl)
+	}
+
+	for _, from := range ids {
+		tos := make([]string, 0, len(g.Edges[from]))
+		for to := range g.Edges[from] {
+			tos = append(tos, to)
+		}
+		sort.Strings(tos)
+
+		for _, to := range tos {
+			for _, edge := range g.Edges[from][to] {
+				doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdgeEl{
+					Source: from,
+					Target: to,
+					Data: []graphMLDate{
+						{Key: graphMLEdgeWeightKeyID, Value: strconv.FormatFloat(edge.Weight, 'f', -1, 64)},
+					},
+				})
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write GraphML header: %v", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode GraphML: %v", err)
+	}
+	return nil
+}
+
+// LoadGraphML replaces the graph's contents with the GraphML document read
+// from filename.
+func (g *Graph) LoadGraphML(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	return g.ReadGraphML(file)
+}
+
+// ReadGraphML replaces the graph's contents with the GraphML document read
+// from r.
+func (g *Graph) ReadGraphML(r io.Reader) error {
+	var doc graphMLDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return fmt.Errorf("failed to decode GraphML: %v", err)
+	}
+
+	var nodeDataKey, nodeDataKindKey, edgeWeightKey string
+	for _, key := range doc.Keys {
+		switch {
+		case key.For == "node" && key.AttrName == "data":
+			nodeDataKey = key.ID
+		case key.For == "node" && key.AttrName == "dataKind":
+			nodeDataKindKey = key.ID
+		case key.For == "edge" && key.AttrName == "weight":
+			edgeWeightKey = key.ID
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Nodes = make(map[string]*Node)
+	g.Edges = make(map[string]map[string][]*Edge)
+	g.typeIndex = make(map[string]map[string]*Node)
+
+	for _, nodeEl := range doc.Graph.Nodes {
+		if nodeEl.ID == "" {
+			return fmt.Errorf("malformed GraphML: node missing id attribute")
+		}
+
+		var rawValue string
+		var kind string
+		var hasValue bool
+		for _, d := range nodeEl.Data {
+			switch d.Key {
+			case nodeDataKey:
+				rawValue, hasValue = d.Value, true
+			case nodeDataKindKey:
+				kind = d.Value
+			}
+		}
+
+		var data interface{}
+		if hasValue {
+			data = graphMLDecodeValue(rawValue, kind)
+		}
+
+		node := &Node{ID: nodeEl.ID, Data: data}
+		g.Nodes[nodeEl.ID] = node
+		g.Edges[nodeEl.ID] = make(map[string][]*Edge)
+		g.indexNodeType(node)
+	}
+
+	undirected := doc.Graph.EdgeDefault == "undirected"
+
+	for _, edgeEl := range doc.Graph.Edges {
+		if _, ok := g.Nodes[edgeEl.Source]; !ok {
+			return fmt.Errorf("malformed GraphML: edge source %q is not a declared node", edgeEl.Source)
+		}
+		if _, ok := g.Nodes[edgeEl.Target]; !ok {
+			return fmt.Errorf("malformed GraphML: edge target %q is not a declared node", edgeEl.Target)
+		}
+
+		var weight float64
+		for _, d := range edgeEl.Data {
+			if d.Key == edgeWeightKey {
+				w, err := strconv.ParseFloat(d.Value, 64)
+				if err != nil {
+					return fmt.Errorf("malformed GraphML: edge %s->%s has non-numeric weight %q", edgeEl.Source, edgeEl.Target, d.Value)
+				}
+				weight = w
+				break
+			}
+		}
+
+		g.Edges[edgeEl.Source][edgeEl.Target] = append(g.Edges[edgeEl.Source][edgeEl.Target],
+			&Edge{From: edgeEl.Source, To: edgeEl.Target, Weight: weight})
+		if undirected {
+			g.Edges[edgeEl.Target][edgeEl.Source] = append(g.Edges[edgeEl.Target][edgeEl.Source],
+				&Edge{From: edgeEl.Target, To: edgeEl.Source, Weight: weight})
+		}
+	}
+
+	return nil
+}
+
+// graphMLEncodeValue renders a node's Data for storage in a <data> element.
+// Plain strings are stored as-is for readability; everything else
+// (numbers, bools, maps, structs) is JSON-encoded so it can be decoded back
+// to an equivalent interface{} by graphMLDecodeValue. The returned kind
+// (graphMLDataKindString or graphMLDataKindJSON) is written alongside the
+// value as a second <data> element so decoding never has to guess which
+// case it's in; nil data is written as neither and kind is "".
+func graphMLEncodeValue(data interface{}) (value string, kind string, err error) {
+	if data == nil {
+		return "", "", nil
+	}
+	if s, ok := data.(string); ok {
+		return s, graphMLDataKindString, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", "", err
+	}
+	return string(b), graphMLDataKindJSON, nil
+}
+
+// graphMLDecodeValue reverses graphMLEncodeValue. kind disambiguates a
+// string that happens to also be valid JSON (e.g. "42", "true", "null")
+// from an actual JSON-encoded value; a file produced by WriteGraphML
+// always sets it. For GraphML from other producers, which won't have a
+// dataKind attribute, kind is "" and decoding falls back to the same
+// best-effort JSON-then-raw-string guess this package has always used.
+func graphMLDecodeValue(value, kind string) interface{} {
+	switch kind {
+	case graphMLDataKindString:
+		return value
+	case graphMLDataKindJSON:
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			return v
+		}
+		return value
+	default:
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			return v
+		}
+		return value
+	}
+}
@@ -0,0 +1,218 @@
+package gropher
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGraphMLRoundTrip(t *testing.T) {
+	g := New()
+	filename := "test_graph.graphml"
+
+	t.Run("Save and Load Empty Graph", func(t *testing.T) {
+		if err := g.SaveGraphML(filename); err != nil {
+			t.Errorf("Failed to save empty graph: %v", err)
+		}
+
+		newGraph := New()
+		if err := newGraph.LoadGraphML(filename); err != nil {
+			t.Errorf("Failed to load empty graph: %v", err)
+		}
+	})
+
+	t.Run("Save and Load Complex Graph", func(t *testing.T) {
+		// Same node/edge set as TestGraphSerialization's complex case.
+		testData := []struct {
+			id   string
+			data interface{}
+		}{
+			{"1", "String data"},
+			{"2", 42},
+			{"3", map[string]interface{}{"name": "Test", "value": 100}},
+			{"4", struct {
+				Name  string `json:"name"`
+				Value int    `json:"value"`
+			}{"Test Struct", 200}},
+		}
+
+		for _, td := range testData {
+			if err := g.AddNode(td.id, td.data); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		edges := []struct {
+			from   string
+			to     string
+			weight float64
+		}{
+			{"1", "2", 1.0},
+			{"2", "3", 2.5},
+			{"3", "4", 0.5},
+			{"4", "1", 1.5},
+		}
+
+		for _, edge := range edges {
+			if err := g.AddEdge(edge.from, edge.to, edge.weight); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := g.SaveGraphML(filename); err != nil {
+			t.Errorf("Failed to save graph: %v", err)
+		}
+
+		newGraph := New()
+		if err := newGraph.LoadGraphML(filename); err != nil {
+			t.Errorf("Failed to load graph: %v", err)
+		}
+
+		for _, td := range testData {
+			node, err := newGraph.GetNode(td.id)
+			if err != nil {
+				t.Errorf("Failed to get node %s from loaded graph: %v", td.id, err)
+				continue
+			}
+
+			expectedJSON, _ := json.Marshal(td.data)
+			actualJSON, _ := json.Marshal(node.Data)
+			if string(expectedJSON) != string(actualJSON) {
+				t.Errorf("Node %s data mismatch: expected %s, got %s", td.id, expectedJSON, actualJSON)
+			}
+		}
+
+		for _, edge := range edges {
+			loaded, err := newGraph.GetNeighbors(edge.from)
+			if err != nil {
+				t.Errorf("Failed to get neighbors for node %s: %v", edge.from, err)
+				continue
+			}
+
+			found := false
+			for _, neighbor := range loaded {
+				if neighbor.ID == edge.to {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Edge %s->%s not found in loaded graph", edge.from, edge.to)
+			}
+
+			got := newGraph.Edges[edge.from][edge.to]
+			if len(got) != 1 || got[0].Weight != edge.weight {
+				t.Errorf("Edge %s->%s weight mismatch: expected %v, got %+v", edge.from, edge.to, edge.weight, got)
+			}
+		}
+	})
+
+	t.Run("Resolves Keys By Attribute Name Not Position", func(t *testing.T) {
+		// A spec-compliant producer may declare the weight key before the
+		// data key; resolution must not assume d0/d1 positionally.
+		xmlDoc := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="w" for="edge" attr.name="weight" attr.type="double"/>
+  <key id="n" for="node" attr.name="data" attr.type="string"/>
+  <graph edgedefault="directed">
+    <node id="a"><data key="n">hello</data></node>
+    <node id="b"></node>
+    <edge source="a" target="b"><data key="w">3.5</data></edge>
+  </graph>
+</graphml>`
+
+		newGraph := New()
+		if err := newGraph.ReadGraphML(strings.NewReader(xmlDoc)); err != nil {
+			t.Fatalf("Failed to read GraphML: %v", err)
+		}
+
+		node, err := newGraph.GetNode("a")
+		if err != nil {
+			t.Fatalf("Failed to get node a: %v", err)
+		}
+		if node.Data != "hello" {
+			t.Errorf("Expected node a data %q, got %v", "hello", node.Data)
+		}
+
+		edges := newGraph.Edges["a"]["b"]
+		if len(edges) != 1 || edges[0].Weight != 3.5 {
+			t.Errorf("Expected edge a->b weight 3.5, got %+v", edges)
+		}
+	})
+
+	t.Run("Undirected Graph Adds Reverse Edges", func(t *testing.T) {
+		xmlDoc := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d1" for="edge" attr.name="weight" attr.type="double"/>
+  <graph edgedefault="undirected">
+    <node id="a"></node>
+    <node id="b"></node>
+    <edge source="a" target="b"><data key="d1">1</data></edge>
+  </graph>
+</graphml>`
+
+		newGraph := New()
+		if err := newGraph.ReadGraphML(strings.NewReader(xmlDoc)); err != nil {
+			t.Fatalf("Failed to read GraphML: %v", err)
+		}
+
+		if len(newGraph.Edges["a"]["b"]) == 0 {
+			t.Error("Expected forward edge a->b")
+		}
+		if len(newGraph.Edges["b"]["a"]) == 0 {
+			t.Error("Expected reverse edge b->a for undirected graph")
+		}
+	})
+
+	t.Run("Malformed XML Returns Error", func(t *testing.T) {
+		newGraph := New()
+		err := newGraph.ReadGraphML(strings.NewReader("<graphml><graph>"))
+		if err == nil {
+			t.Error("Expected error when reading malformed GraphML")
+		}
+	})
+
+	t.Run("Edge Referencing Unknown Node Returns Error", func(t *testing.T) {
+		xmlDoc := `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <graph edgedefault="directed">
+    <node id="a"></node>
+    <edge source="a" target="missing"></edge>
+  </graph>
+</graphml>`
+
+		newGraph := New()
+		err := newGraph.ReadGraphML(strings.NewReader(xmlDoc))
+		if err == nil {
+			t.Error("Expected error when edge references an unknown node")
+		}
+	})
+
+	t.Run("String Data That Looks Like JSON Round-Trips As A String", func(t *testing.T) {
+		for _, data := range []string{"42", "true", "null", "[1,2]"} {
+			g := New()
+			if err := g.AddNode("a", data); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf strings.Builder
+			if err := g.WriteGraphML(&buf); err != nil {
+				t.Fatalf("Failed to write GraphML for %q: %v", data, err)
+			}
+
+			newGraph := New()
+			if err := newGraph.ReadGraphML(strings.NewReader(buf.String())); err != nil {
+				t.Fatalf("Failed to read GraphML for %q: %v", data, err)
+			}
+
+			node, err := newGraph.GetNode("a")
+			if err != nil {
+				t.Fatalf("Failed to get node a for %q: %v", data, err)
+			}
+			if node.Data != data {
+				t.Errorf("Expected node a data to round-trip as the string %q, got %#v", data, node.Data)
+			}
+		}
+	})
+
+	os.Remove(filename)
+}
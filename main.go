@@ -7,53 +7,90 @@ import (
 	"sync"
 )
 
-// Node represents a vertex in the graph with generic data
+// Node represents a vertex in the graph with generic data. Type is
+// optional; when set, the node is indexed for lookup via NodesByType.
 type Node struct {
 	ID   string      `json:"id"`
+	Type string      `json:"type,omitempty"`
 	Data interface{} `json:"data"`
 }
 
-// Edge represents a connection between two Nodes
+// Edge represents a connection between two Nodes. Key distinguishes
+// parallel edges between the same pair of Nodes; the simple AddEdge/
+// RemoveEdge API always uses the empty-string key, so existing
+// single-edge-per-pair usage is unaffected. Kind and the Cascade* flags are
+// set via AddTypedEdge and drive RemoveNode's cascade-delete behavior; see
+// EdgeOptions.
 type Edge struct {
-	From   string  `json:"from"`
-	To     string  `json:"to"`
-	Weight float64 `json:"weight"`
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Key    string      `json:"key,omitempty"`
+	Kind   string      `json:"kind,omitempty"`
+	Weight float64     `json:"weight"`
+	Data   interface{} `json:"data,omitempty"`
+
+	CascadeToTarget       bool `json:"cascadeToTarget,omitempty"`
+	CascadeFromTarget     bool `json:"cascadeFromTarget,omitempty"`
+	CascadeLastToTarget   bool `json:"cascadeLastToTarget,omitempty"`
+	CascadeLastFromTarget bool `json:"cascadeLastFromTarget,omitempty"`
 }
 
-// Graph represents a directed weighted graph
+// Graph represents a directed weighted multigraph: Edges maps a source id
+// to a target id to the (possibly several) parallel edges between them.
 type Graph struct {
 	Nodes map[string]*Node
-	Edges map[string]map[string]*Edge
+	Edges map[string]map[string][]*Edge
 	mu    sync.RWMutex
+
+	// typeIndex maps a Node's Type to its Nodes, keyed by id, so
+	// NodesByType doesn't need a full scan.
+	typeIndex map[string]map[string]*Node
 }
 
 // New creates a new empty graph
 func New() *Graph {
 	return &Graph{
-		Nodes: make(map[string]*Node),
-		Edges: make(map[string]map[string]*Edge),
+		Nodes:     make(map[string]*Node),
+		Edges:     make(map[string]map[string][]*Edge),
+		typeIndex: make(map[string]map[string]*Node),
 	}
 }
 
-// AddNode adds a new node to the graph
+// AddNode adds a new, untyped node to the graph. Use AddTypedNode to set
+// Node.Type so the node is queryable via NodesByType.
 func (g *Graph) AddNode(id string, data interface{}) error {
+	return g.AddTypedNode(id, "", data)
+}
+
+// AddTypedNode adds a new node with an explicit Type to the graph.
+func (g *Graph) AddTypedNode(id, typ string, data interface{}) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	return g.addNodeLocked(id, typ, data)
+}
+
+// addNodeLocked inserts a new node, failing if id is already present.
+// Callers must hold g.mu for writing.
+func (g *Graph) addNodeLocked(id, typ string, data interface{}) error {
 	if _, exists := g.Nodes[id]; exists {
 		return fmt.Errorf("node with ID %s already exists", id)
 	}
 
-	g.Nodes[id] = &Node{
+	node := &Node{
 		ID:   id,
+		Type: typ,
 		Data: data,
 	}
-
-	g.Edges[id] = make(map[string]*Edge)
+	g.Nodes[id] = node
+	g.Edges[id] = make(map[string][]*Edge)
+	g.indexNodeType(node)
 	return nil
 }
 
-// RemoveNode removes a node and all its Edges from the graph
+// RemoveNode removes a node and all its Edges from the graph. Edges whose
+// Cascade* flags are set (see EdgeOptions) may cause RemoveNode to remove
+// further Nodes in turn.
 func (g *Graph) RemoveNode(id string) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -62,47 +99,114 @@ func (g *Graph) RemoveNode(id string) error {
 		return fmt.Errorf("node with ID %s does not exist", id)
 	}
 
-	// Remove all Edges connected to this node
+	g.cascadeRemoveNodeLocked(id, make(map[string]bool))
+	return nil
+}
+
+// removeNodeLocked removes a node and all Edges referencing it as either
+// endpoint. Callers must hold g.mu for writing and ensure id exists.
+func (g *Graph) removeNodeLocked(id string) {
+	node := g.Nodes[id]
+
 	delete(g.Edges, id)
 	for _, edges := range g.Edges {
 		delete(edges, id)
 	}
 
 	delete(g.Nodes, id)
-	return nil
+	g.unindexNodeType(node)
+}
+
+// NodesByType returns every node whose Type matches t. The caller's
+// modifications to the returned slice do not affect the graph.
+func (g *Graph) NodesByType(t string) []*Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	nodes := make([]*Node, 0, len(g.typeIndex[t]))
+	for _, node := range g.typeIndex[t] {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// indexNodeType adds node to the type index. Callers must hold g.mu.
+func (g *Graph) indexNodeType(node *Node) {
+	if g.typeIndex[node.Type] == nil {
+		g.typeIndex[node.Type] = make(map[string]*Node)
+	}
+	g.typeIndex[node.Type][node.ID] = node
+}
+
+// unindexNodeType removes node from the type index. Callers must hold g.mu.
+func (g *Graph) unindexNodeType(node *Node) {
+	delete(g.typeIndex[node.Type], node.ID)
 }
 
-// AddEdge adds a new edge between two Nodes
+// AddEdge adds a new edge between two Nodes, using the empty key. Calling
+// it again for the same (from, to) pair replaces that edge rather than
+// adding a parallel one; use AddEdgeKeyed for true multi-edges.
 func (g *Graph) AddEdge(from, to string, weight float64) error {
+	return g.AddEdgeKeyed(from, to, "", weight, nil)
+}
+
+// AddEdgeKeyed adds an edge between two Nodes identified by key, allowing
+// multiple parallel edges between the same pair as long as their keys
+// differ. Adding an edge with a key that already exists between the pair
+// replaces it.
+func (g *Graph) AddEdgeKeyed(from, to, key string, weight float64, data interface{}) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	if _, exists := g.Nodes[from]; !exists {
-		return fmt.Errorf("source node %s does not exist", from)
+	return g.addEdgeLocked(&Edge{From: from, To: to, Key: key, Weight: weight, Data: data})
+}
+
+// addEdgeLocked inserts or replaces edge. Callers must hold g.mu for
+// writing and ensure edge.From/edge.To are non-empty.
+func (g *Graph) addEdgeLocked(edge *Edge) error {
+	if _, exists := g.Nodes[edge.From]; !exists {
+		return fmt.Errorf("source node %s does not exist", edge.From)
 	}
-	if _, exists := g.Nodes[to]; !exists {
-		return fmt.Errorf("destination node %s does not exist", to)
+	if _, exists := g.Nodes[edge.To]; !exists {
+		return fmt.Errorf("destination node %s does not exist", edge.To)
 	}
 
-	g.Edges[from][to] = &Edge{
-		From:   from,
-		To:     to,
-		Weight: weight,
+	edges := g.Edges[edge.From][edge.To]
+	for i, existing := range edges {
+		if existing.Key == edge.Key {
+			edges[i] = edge
+			return nil
+		}
 	}
+	g.Edges[edge.From][edge.To] = append(edges, edge)
 	return nil
 }
 
-// RemoveEdge removes an edge between two Nodes
+// RemoveEdge removes the empty-keyed edge between two Nodes, leaving any
+// other parallel edges between them intact.
 func (g *Graph) RemoveEdge(from, to string) error {
+	return g.RemoveEdgeKeyed(from, to, "")
+}
+
+// RemoveEdgeKeyed removes the edge identified by key between two Nodes.
+func (g *Graph) RemoveEdgeKeyed(from, to, key string) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	if _, exists := g.Edges[from][to]; !exists {
-		return fmt.Errorf("edge from %s to %s does not exist", from, to)
-	}
+	return g.removeEdgeLocked(from, to, key)
+}
 
-	delete(g.Edges[from], to)
-	return nil
+// removeEdgeLocked removes the edge identified by key between from and to.
+// Callers must hold g.mu for writing.
+func (g *Graph) removeEdgeLocked(from, to, key string) error {
+	edges := g.Edges[from][to]
+	for i, existing := range edges {
+		if existing.Key == key {
+			g.Edges[from][to] = append(edges[:i], edges[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("edge from %s to %s does not exist", from, to)
 }
 
 // GetNode returns a node by its ID
@@ -117,7 +221,8 @@ func (g *Graph) GetNode(id string) (*Node, error) {
 	return node, nil
 }
 
-// GetNeighbors returns all Nodes connected to the given node
+// GetNeighbors returns all Nodes connected to the given node. A target
+// reachable via several parallel edges is only returned once.
 func (g *Graph) GetNeighbors(id string) ([]*Node, error) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
@@ -127,16 +232,47 @@ func (g *Graph) GetNeighbors(id string) ([]*Node, error) {
 	}
 
 	var neighbors []*Node
-	for toID := range g.Edges[id] {
+	for toID, edges := range g.Edges[id] {
+		if len(edges) == 0 {
+			continue
+		}
 		neighbors = append(neighbors, g.Nodes[toID])
 	}
 	return neighbors, nil
 }
 
+// GetEdges returns every edge from "from" to "to", including parallel
+// edges added via AddEdgeKeyed.
+func (g *Graph) GetEdges(from, to string) ([]*Edge, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if _, exists := g.Nodes[from]; !exists {
+		return nil, fmt.Errorf("source node %s does not exist", from)
+	}
+
+	edges := g.Edges[from][to]
+	if len(edges) == 0 {
+		return nil, fmt.Errorf("edge from %s to %s does not exist", from, to)
+	}
+
+	result := make([]*Edge, len(edges))
+	copy(result, edges)
+	return result, nil
+}
+
+// graphSchemaVersion is written into every file SaveToFile produces.
+// LoadFromFile rejects a file with a higher version and decodes any other
+// (including one with no version field) using the current layout, in which
+// each (from, to) pair maps to a list of parallel edges; a file whose edges
+// are stored as single objects rather than lists fails to decode.
+const graphSchemaVersion = 2
+
 // graphData is used for JSON serialization
 type graphData struct {
-	Nodes []*Node                     `json:"Nodes"`
-	Edges map[string]map[string]*Edge `json:"Edges"`
+	Version int                           `json:"version"`
+	Nodes   []*Node                       `json:"nodes"`
+	Edges   map[string]map[string][]*Edge `json:"edges"`
 }
 
 // SaveToFile saves the graph to a JSON file
@@ -145,7 +281,8 @@ func (g *Graph) SaveToFile(filename string) error {
 	defer g.mu.RUnlock()
 
 	data := graphData{
-		Edges: g.Edges,
+		Version: graphSchemaVersion,
+		Edges:   g.Edges,
 	}
 
 	for _, node := range g.Nodes {
@@ -182,21 +319,26 @@ func (g *Graph) LoadFromFile(filename string) error {
 	if err := decoder.Decode(&data); err != nil {
 		return fmt.Errorf("failed to decode graph: %v", err)
 	}
+	if data.Version > graphSchemaVersion {
+		return fmt.Errorf("unsupported graph schema version %d (max supported %d)", data.Version, graphSchemaVersion)
+	}
 
 	// Clear existing graph
 	g.Nodes = make(map[string]*Node)
-	g.Edges = make(map[string]map[string]*Edge)
+	g.Edges = make(map[string]map[string][]*Edge)
+	g.typeIndex = make(map[string]map[string]*Node)
 
 	// Restore Nodes
 	for _, node := range data.Nodes {
 		g.Nodes[node.ID] = node
-		g.Edges[node.ID] = make(map[string]*Edge)
+		g.Edges[node.ID] = make(map[string][]*Edge)
+		g.indexNodeType(node)
 	}
 
 	// Restore Edges
 	for fromID, edges := range data.Edges {
-		for toID, edge := range edges {
-			g.Edges[fromID][toID] = edge
+		for toID, edgeList := range edges {
+			g.Edges[fromID][toID] = edgeList
 		}
 	}
 
@@ -0,0 +1,136 @@
+package gropher
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMultigraph(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+	g.AddNode("b", nil)
+
+	t.Run("AddEdgeKeyed Allows Parallel Edges", func(t *testing.T) {
+		if err := g.AddEdgeKeyed("a", "b", "flight1", 100, "United"); err != nil {
+			t.Fatalf("AddEdgeKeyed returned error: %v", err)
+		}
+		if err := g.AddEdgeKeyed("a", "b", "flight2", 150, "Delta"); err != nil {
+			t.Fatalf("AddEdgeKeyed returned error: %v", err)
+		}
+
+		edges, err := g.GetEdges("a", "b")
+		if err != nil {
+			t.Fatalf("GetEdges returned error: %v", err)
+		}
+		if len(edges) != 2 {
+			t.Fatalf("Expected 2 parallel edges, got %d", len(edges))
+		}
+	})
+
+	t.Run("AddEdgeKeyed Replaces Same Key", func(t *testing.T) {
+		if err := g.AddEdgeKeyed("a", "b", "flight1", 200, "United"); err != nil {
+			t.Fatalf("AddEdgeKeyed returned error: %v", err)
+		}
+
+		edges, err := g.GetEdges("a", "b")
+		if err != nil {
+			t.Fatalf("GetEdges returned error: %v", err)
+		}
+		if len(edges) != 2 {
+			t.Fatalf("Expected replacing a key not to add a new edge, got %d edges", len(edges))
+		}
+		for _, e := range edges {
+			if e.Key == "flight1" && e.Weight != 200 {
+				t.Errorf("Expected flight1 weight to be updated to 200, got %v", e.Weight)
+			}
+		}
+	})
+
+	t.Run("RemoveEdgeKeyed Removes Only That Edge", func(t *testing.T) {
+		if err := g.RemoveEdgeKeyed("a", "b", "flight1"); err != nil {
+			t.Fatalf("RemoveEdgeKeyed returned error: %v", err)
+		}
+
+		edges, err := g.GetEdges("a", "b")
+		if err != nil {
+			t.Fatalf("GetEdges returned error: %v", err)
+		}
+		if len(edges) != 1 || edges[0].Key != "flight2" {
+			t.Errorf("Expected only flight2 to remain, got %+v", edges)
+		}
+	})
+
+	t.Run("RemoveEdgeKeyed Unknown Key Errors", func(t *testing.T) {
+		if err := g.RemoveEdgeKeyed("a", "b", "nonexistent"); err == nil {
+			t.Error("Expected error when removing an unknown keyed edge")
+		}
+	})
+
+	t.Run("AddEdge/RemoveEdge Use The Empty Key Alongside Keyed Edges", func(t *testing.T) {
+		if err := g.AddEdge("a", "b", 42); err != nil {
+			t.Fatalf("AddEdge returned error: %v", err)
+		}
+
+		edges, err := g.GetEdges("a", "b")
+		if err != nil {
+			t.Fatalf("GetEdges returned error: %v", err)
+		}
+		if len(edges) != 2 {
+			t.Fatalf("Expected AddEdge to add alongside flight2, got %d edges", len(edges))
+		}
+
+		if err := g.RemoveEdge("a", "b"); err != nil {
+			t.Fatalf("RemoveEdge returned error: %v", err)
+		}
+
+		edges, err = g.GetEdges("a", "b")
+		if err != nil {
+			t.Fatalf("GetEdges returned error: %v", err)
+		}
+		if len(edges) != 1 || edges[0].Key != "flight2" {
+			t.Errorf("Expected only flight2 to remain after RemoveEdge, got %+v", edges)
+		}
+	})
+
+	t.Run("GetEdges Unknown Pair Errors", func(t *testing.T) {
+		if _, err := g.GetEdges("a", "nonexistent"); err == nil {
+			t.Error("Expected error for a pair with no edges")
+		}
+	})
+}
+
+func TestMultigraphNetworkXRoundTrip(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+	g.AddNode("b", nil)
+	g.AddEdgeKeyed("a", "b", "flight1", 100, nil)
+	g.AddEdgeKeyed("a", "b", "flight2", 150, nil)
+
+	filename := "test_multigraph.json"
+	defer os.Remove(filename)
+
+	if err := g.SaveNetworkXJSON(filename); err != nil {
+		t.Fatalf("SaveNetworkXJSON returned error: %v", err)
+	}
+
+	loaded := New()
+	if err := loaded.LoadNetworkXJSON(filename); err != nil {
+		t.Fatalf("LoadNetworkXJSON returned error: %v", err)
+	}
+
+	edges, err := loaded.GetEdges("a", "b")
+	if err != nil {
+		t.Fatalf("GetEdges returned error: %v", err)
+	}
+	if len(edges) != 2 {
+		t.Fatalf("Expected 2 parallel edges after round trip, got %d", len(edges))
+	}
+
+	keys := map[string]bool{}
+	for _, e := range edges {
+		keys[e.Key] = true
+	}
+	if !keys["flight1"] || !keys["flight2"] {
+		t.Errorf("Expected both flight1 and flight2 keys to round-trip, got %+v", edges)
+	}
+}
@@ -2,7 +2,6 @@ package gropher
 
 import (
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"os"
 )
@@ -22,55 +21,22 @@ type NetworkXNode struct {
 	Data map[string]interface{} `json:"data,omitempty"`
 }
 
-// NetworkXLink represents an edge in NetworkX JSON format
+// NetworkXLink represents an edge in NetworkX JSON format. Key distinguishes
+// parallel edges, as in NetworkX; it is written whenever non-empty and read
+// back whether or not the enclosing NetworkXJSON has Multigraph set.
 type NetworkXLink struct {
 	Source string  `json:"source"`
 	Target string  `json:"target"`
+	Key    string  `json:"key,omitempty"`
 	Weight float64 `json:"weight,omitempty"`
 }
 
 // SaveNetworkXJSON saves the graph in NetworkX-compatible JSON format
 func (g *Graph) SaveNetworkXJSON(filename string) error {
 	g.mu.RLock()
-	defer g.mu.RUnlock()
-
-	nx := NetworkXJSON{
-		Directed:   true,
-		Multigraph: false,
-		Graph:      make(map[string]interface{}),
-	}
+	nx := graphToNetworkX(g)
+	g.mu.RUnlock()
 
-	// Convert Nodes
-	for id, node := range g.Nodes {
-		nxNode := NetworkXNode{
-			ID: id,
-		}
-
-		// Convert node data to map if possible
-		if data, ok := node.Data.(map[string]interface{}); ok {
-			nxNode.Data = data
-		} else {
-			// If data is not a map, store it under a "value" key
-			nxNode.Data = map[string]interface{}{
-				"value": node.Data,
-			}
-		}
-
-		nx.Nodes = append(nx.Nodes, nxNode)
-	}
-
-	// Convert Edges
-	for from, edges := range g.Edges {
-		for to, edge := range edges {
-			nx.Links = append(nx.Links, NetworkXLink{
-				Source: from,
-				Target: to,
-				Weight: edge.Weight,
-			})
-		}
-	}
-
-	// Write to file
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %v", err)
@@ -88,9 +54,6 @@ func (g *Graph) SaveNetworkXJSON(filename string) error {
 
 // LoadNetworkXJSON loads a graph from NetworkX-compatible JSON format
 func (g *Graph) LoadNetworkXJSON(filename string) error {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
 	file, err := os.Open(filename)
 	if err != nil {
 		return fmt.Errorf("failed to open file: %v", err)
@@ -103,51 +66,10 @@ func (g *Graph) LoadNetworkXJSON(filename string) error {
 		return fmt.Errorf("failed to decode NetworkX JSON: %v", err)
 	}
 
-	// Clear existing graph
-	g.Nodes = make(map[string]*Node)
-	g.Edges = make(map[string]map[string]*Edge)
-
-	// Add Nodes
-	for _, nxNode := range nx.Nodes {
-		var data interface{} = nxNode.Data
-		// If data has only a "value" key, simplify it
-		if val, ok := nxNode.Data["value"]; ok && len(nxNode.Data) == 1 {
-			data = val
-		}
-
-		g.Nodes[nxNode.ID] = &Node{
-			ID:   nxNode.ID,
-			Data: data,
-		}
-		g.Edges[nxNode.ID] = make(map[string]*Edge)
-	}
-
-	// Add Edges
-	for _, link := range nx.Links {
-		g.Edges[link.Source][link.Target] = &Edge{
-			From:   link.Source,
-			To:     link.Target,
-			Weight: link.Weight,
-		}
-	}
-
-	return nil
-}
-
-// GraphML support can be added here similarly
-type GraphML struct {
-	XMLName xml.Name `xml:"graphml"`
-	// Add GraphML structure
-}
-
-// Future implementation for GraphML format
-func (g *Graph) SaveGraphML(filename string) error {
-	// Implement GraphML export
-	return nil
-}
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
-func (g *Graph) LoadGraphML(filename string) error {
-	// Implement GraphML import
+	applyNetworkXToGraph(g, nx)
 	return nil
 }
 
@@ -162,43 +84,90 @@ func ConvertToNetworkX(g *Graph) (NetworkXJSON, error) {
 		return NetworkXJSON{}, fmt.Errorf("nil graph provided")
 	}
 
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return graphToNetworkX(g), nil
+}
+
+// graphToNetworkX builds the NetworkXJSON representation of g. Multigraph
+// is set when any pair of Nodes is connected by more than one Edge, so
+// SaveNetworkXJSON/LoadNetworkXJSON round-trip parallel edges' keys.
+// Callers must hold at least a read lock on g.
+func graphToNetworkX(g *Graph) NetworkXJSON {
 	nx := NetworkXJSON{
-		Directed:   true,
-		Multigraph: false,
-		Graph:      make(map[string]interface{}),
+		Directed: true,
+		Graph:    make(map[string]interface{}),
 	}
 
-	// Convert Nodes
 	for id, node := range g.Nodes {
-		nxNode := NetworkXNode{
-			ID: id,
-		}
+		nx.Nodes = append(nx.Nodes, nodeToNetworkXNode(id, node))
+	}
 
-		// Convert node data to map if possible
-		if data, ok := node.Data.(map[string]interface{}); ok {
-			nxNode.Data = data
-		} else {
-			// If data is not a map, store it under a "value" key
-			nxNode.Data = map[string]interface{}{
-				"value": node.Data,
+	for from, edges := range g.Edges {
+		for to, parallel := range edges {
+			if len(parallel) > 1 {
+				nx.Multigraph = true
+			}
+			for _, edge := range parallel {
+				nx.Links = append(nx.Links, NetworkXLink{
+					Source: from,
+					Target: to,
+					Key:    edge.Key,
+					Weight: edge.Weight,
+				})
 			}
 		}
+	}
+
+	return nx
+}
+
+// applyNetworkXToGraph replaces g's Nodes/Edges with those decoded from nx.
+// The caller must hold the write lock on g.
+func applyNetworkXToGraph(g *Graph, nx NetworkXJSON) {
+	g.Nodes = make(map[string]*Node)
+	g.Edges = make(map[string]map[string][]*Edge)
+	g.typeIndex = make(map[string]map[string]*Node)
 
-		nx.Nodes = append(nx.Nodes, nxNode)
+	for _, nxNode := range nx.Nodes {
+		node := &Node{ID: nxNode.ID, Data: networkXNodeData(nxNode)}
+		g.Nodes[nxNode.ID] = node
+		g.Edges[nxNode.ID] = make(map[string][]*Edge)
+		g.indexNodeType(node)
 	}
 
-	// Convert Edges
-	for from, edges := range g.Edges {
-		for to, edge := range edges {
-			nx.Links = append(nx.Links, NetworkXLink{
-				Source: from,
-				Target: to,
-				Weight: edge.Weight,
-			})
-		}
+	for _, link := range nx.Links {
+		g.Edges[link.Source][link.Target] = append(g.Edges[link.Source][link.Target], &Edge{
+			From:   link.Source,
+			To:     link.Target,
+			Key:    link.Key,
+			Weight: link.Weight,
+		})
+	}
+}
+
+// nodeToNetworkXNode converts a Node to its NetworkX JSON form, wrapping
+// non-map Data under a "value" key so it survives the map[string]interface{}
+// shape NetworkX expects.
+func nodeToNetworkXNode(id string, node *Node) NetworkXNode {
+	nxNode := NetworkXNode{ID: id}
+
+	if data, ok := node.Data.(map[string]interface{}); ok {
+		nxNode.Data = data
+	} else {
+		nxNode.Data = map[string]interface{}{"value": node.Data}
 	}
 
-	return nx, nil
+	return nxNode
+}
+
+// networkXNodeData reverses nodeToNetworkXNode's "value" wrapping.
+func networkXNodeData(nxNode NetworkXNode) interface{} {
+	if val, ok := nxNode.Data["value"]; ok && len(nxNode.Data) == 1 {
+		return val
+	}
+	return nxNode.Data
 }
 
 // addGraphsToFile converts multiple graphs to NetworkX format and saves them to a file
@@ -213,11 +182,7 @@ func SaveGraphsToFile(graphs []*Graph, filename string) error {
 			return fmt.Errorf("nil graph at index %d", i)
 		}
 
-		// Lock the graph for reading
-		g.mu.RLock()
 		nx, err := ConvertToNetworkX(g)
-		g.mu.RUnlock()
-
 		if err != nil {
 			return fmt.Errorf("failed to convert graph %d: %v", i, err)
 		}
@@ -260,31 +225,7 @@ func LoadGraphsFromFile(filename string) ([]*Graph, error) {
 	graphs := make([]*Graph, 0, len(collection.Graphs))
 	for _, nx := range collection.Graphs {
 		g := New()
-
-		// Add Nodes
-		for _, nxNode := range nx.Nodes {
-			var data interface{} = nxNode.Data
-			// If data has only a "value" key, simplify it
-			if val, ok := nxNode.Data["value"]; ok && len(nxNode.Data) == 1 {
-				data = val
-			}
-
-			g.Nodes[nxNode.ID] = &Node{
-				ID:   nxNode.ID,
-				Data: data,
-			}
-			g.Edges[nxNode.ID] = make(map[string]*Edge)
-		}
-
-		// Add Edges
-		for _, link := range nx.Links {
-			g.Edges[link.Source][link.Target] = &Edge{
-				From:   link.Source,
-				To:     link.Target,
-				Weight: link.Weight,
-			}
-		}
-
+		applyNetworkXToGraph(g, nx)
 		graphs = append(graphs, g)
 	}
 
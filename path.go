@@ -0,0 +1,305 @@
+package gropher
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// ErrNegativeWeight is returned by ShortestPath/ShortestPathFunc when the
+// search reaches an edge whose cost is negative (edges not reachable from
+// the source are not checked); Dijkstra's algorithm does not produce
+// correct results in that case. Use BellmanFord instead.
+type ErrNegativeWeight struct {
+	From, To string
+	Weight   float64
+}
+
+func (e *ErrNegativeWeight) Error() string {
+	return fmt.Sprintf("gropher: edge %s->%s has negative weight %v, Dijkstra requires non-negative weights", e.From, e.To, e.Weight)
+}
+
+// ShortestPath returns the lowest-cost path from "from" to "to" using
+// Dijkstra's algorithm over the graph's edge weights.
+func (g *Graph) ShortestPath(from, to string) ([]string, float64, error) {
+	return g.ShortestPathFunc(from, to, func(e *Edge) float64 { return e.Weight })
+}
+
+// ShortestPathFunc is like ShortestPath but lets the caller supply the cost
+// of traversing an edge, e.g. to use hop-count or a custom metric instead of
+// the edge's stored Weight.
+func (g *Graph) ShortestPathFunc(from, to string, weight func(*Edge) float64) ([]string, float64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if _, exists := g.Nodes[from]; !exists {
+		return nil, 0, fmt.Errorf("source node %s does not exist", from)
+	}
+	if _, exists := g.Nodes[to]; !exists {
+		return nil, 0, fmt.Errorf("destination node %s does not exist", to)
+	}
+
+	dist, prev, err := g.dijkstra(from, weight)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cost, ok := dist[to]
+	if !ok {
+		return nil, 0, fmt.Errorf("no path from %s to %s", from, to)
+	}
+
+	return reconstructPath(prev, from, to), cost, nil
+}
+
+// AllShortestPaths runs single-source Dijkstra from "from" and returns the
+// shortest distance and predecessor for every node it can reach.
+func (g *Graph) AllShortestPaths(from string) (map[string]float64, map[string]string, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if _, exists := g.Nodes[from]; !exists {
+		return nil, nil, fmt.Errorf("source node %s does not exist", from)
+	}
+
+	return g.dijkstra(from, func(e *Edge) float64 { return e.Weight })
+}
+
+// pqItem is an entry in the Dijkstra priority queue.
+type pqItem struct {
+	id   string
+	dist float64
+}
+
+// priorityQueue is a binary-heap min-priority-queue of pqItems, ordered by
+// distance, for use with container/heap.
+type priorityQueue []*pqItem
+
+func (pq priorityQueue) Len() int            { return len(pq) }
+func (pq priorityQueue) Less(i, j int) bool  { return pq[i].dist < pq[j].dist }
+func (pq priorityQueue) Swap(i, j int)       { pq[i], pq[j] = pq[j], pq[i] }
+func (pq *priorityQueue) Push(x interface{}) { *pq = append(*pq, x.(*pqItem)) }
+func (pq *priorityQueue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	*pq = old[:n-1]
+	return item
+}
+
+// dijkstra computes single-source shortest distances/predecessors from
+// "from" using weight to cost each edge. The caller must hold g.mu.
+func (g *Graph) dijkstra(from string, weight func(*Edge) float64) (map[string]float64, map[string]string, error) {
+	dist := map[string]float64{from: 0}
+	prev := make(map[string]string)
+	visited := make(map[string]bool)
+
+	pq := &priorityQueue{{id: from, dist: 0}}
+	heap.Init(pq)
+
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(*pqItem)
+		if visited[item.id] {
+			continue
+		}
+		visited[item.id] = true
+
+		for to, edges := range g.Edges[item.id] {
+			for _, edge := range edges {
+				w := weight(edge)
+				if w < 0 {
+					return nil, nil, &ErrNegativeWeight{From: edge.From, To: edge.To, Weight: w}
+				}
+
+				newDist := dist[item.id] + w
+				if existing, ok := dist[to]; !ok || newDist < existing {
+					dist[to] = newDist
+					prev[to] = item.id
+					heap.Push(pq, &pqItem{id: to, dist: newDist})
+				}
+			}
+		}
+	}
+
+	return dist, prev, nil
+}
+
+// AStar returns the lowest-cost path from "from" to "to" using the A*
+// algorithm, guided by the heuristic h, which must estimate the remaining
+// cost from a node to "to" without overestimating it (admissible) for the
+// returned path to be optimal. h is evaluated on each candidate node.
+func (g *Graph) AStar(from, to string, h func(id string) float64) ([]string, float64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if _, exists := g.Nodes[from]; !exists {
+		return nil, 0, fmt.Errorf("source node %s does not exist", from)
+	}
+	if _, exists := g.Nodes[to]; !exists {
+		return nil, 0, fmt.Errorf("destination node %s does not exist", to)
+	}
+
+	dist := map[string]float64{from: 0}
+	prev := make(map[string]string)
+	visited := make(map[string]bool)
+
+	pq := &priorityQueue{{id: from, dist: h(from)}}
+	heap.Init(pq)
+
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(*pqItem)
+		if visited[item.id] {
+			continue
+		}
+		visited[item.id] = true
+
+		if item.id == to {
+			break
+		}
+
+		for toID, edges := range g.Edges[item.id] {
+			for _, edge := range edges {
+				if edge.Weight < 0 {
+					return nil, 0, &ErrNegativeWeight{From: edge.From, To: edge.To, Weight: edge.Weight}
+				}
+
+				newDist := dist[item.id] + edge.Weight
+				if existing, ok := dist[toID]; !ok || newDist < existing {
+					dist[toID] = newDist
+					prev[toID] = item.id
+					heap.Push(pq, &pqItem{id: toID, dist: newDist + h(toID)})
+				}
+			}
+		}
+	}
+
+	cost, ok := dist[to]
+	if !ok {
+		return nil, 0, fmt.Errorf("no path from %s to %s", from, to)
+	}
+
+	return reconstructPath(prev, from, to), cost, nil
+}
+
+// BFS performs a breadth-first, unweighted traversal of the graph starting
+// at "from", calling visit with each reached node's id and its depth from
+// "from" (the start node itself is depth 0). Traversal stops early if visit
+// returns false.
+func (g *Graph) BFS(from string, visit func(id string, depth int) bool) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if _, exists := g.Nodes[from]; !exists {
+		return fmt.Errorf("node with ID %s does not exist", from)
+	}
+
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	depth := map[string]int{from: 0}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if !visit(id, depth[id]) {
+			return nil
+		}
+
+		for to, edges := range g.Edges[id] {
+			if len(edges) == 0 {
+				continue
+			}
+			if !visited[to] {
+				visited[to] = true
+				depth[to] = depth[id] + 1
+				queue = append(queue, to)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BellmanFord computes single-source shortest distances/predecessors from
+// "from", tolerating negative edge weights. It returns a *NegativeCycleError
+// if the graph contains a cycle reachable from "from" with negative total
+// weight, in which case shortest paths are undefined.
+func (g *Graph) BellmanFord(from string) (map[string]float64, map[string]string, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if _, exists := g.Nodes[from]; !exists {
+		return nil, nil, fmt.Errorf("source node %s does not exist", from)
+	}
+
+	dist := map[string]float64{from: 0}
+	prev := make(map[string]string)
+
+	for i := 0; i < len(g.Nodes)-1; i++ {
+		changed := false
+		for nodeFrom, edges := range g.Edges {
+			fromDist, ok := dist[nodeFrom]
+			if !ok {
+				continue
+			}
+			for nodeTo, toEdges := range edges {
+				for _, edge := range toEdges {
+					newDist := fromDist + edge.Weight
+					if existing, ok := dist[nodeTo]; !ok || newDist < existing {
+						dist[nodeTo] = newDist
+						prev[nodeTo] = nodeFrom
+						changed = true
+					}
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	for nodeFrom, edges := range g.Edges {
+		fromDist, ok := dist[nodeFrom]
+		if !ok {
+			continue
+		}
+		for nodeTo, toEdges := range edges {
+			for _, edge := range toEdges {
+				if newDist := fromDist + edge.Weight; newDist < dist[nodeTo] {
+					return nil, nil, &NegativeCycleError{}
+				}
+			}
+		}
+	}
+
+	return dist, prev, nil
+}
+
+// NegativeCycleError is returned by BellmanFord when the graph contains a
+// negative-weight cycle reachable from the source node.
+type NegativeCycleError struct{}
+
+func (e *NegativeCycleError) Error() string {
+	return "gropher: graph contains a negative-weight cycle reachable from the source node"
+}
+
+// reconstructPath walks prev backwards from "to" to "from" to build the
+// path in forward order.
+func reconstructPath(prev map[string]string, from, to string) []string {
+	if from == to {
+		return []string{from}
+	}
+
+	var path []string
+	for at := to; ; {
+		path = append(path, at)
+		if at == from {
+			break
+		}
+		at = prev[at]
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
@@ -0,0 +1,309 @@
+package gropher
+
+import (
+	"reflect"
+	"testing"
+)
+
+// buildRoutingGraph builds a small hand-built graph with known shortest
+// paths:
+//
+//	A --1--> B --2--> D
+//	A --4--> D
+//	A --1--> C --1--> D
+//	E is disconnected.
+func buildRoutingGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := New()
+	for _, id := range []string{"A", "B", "C", "D", "E"} {
+		if err := g.AddNode(id, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	edges := []struct {
+		from, to string
+		weight   float64
+	}{
+		{"A", "B", 1},
+		{"B", "D", 2},
+		{"A", "D", 4},
+		{"A", "C", 1},
+		{"C", "D", 1},
+	}
+	for _, e := range edges {
+		if err := g.AddEdge(e.from, e.to, e.weight); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestShortestPath(t *testing.T) {
+	g := buildRoutingGraph(t)
+
+	t.Run("Finds Cheapest Path", func(t *testing.T) {
+		path, cost, err := g.ShortestPath("A", "D")
+		if err != nil {
+			t.Fatalf("ShortestPath returned error: %v", err)
+		}
+		if cost != 2 {
+			t.Errorf("Expected cost 2, got %v", cost)
+		}
+		if !reflect.DeepEqual(path, []string{"A", "C", "D"}) {
+			t.Errorf("Expected path [A C D], got %v", path)
+		}
+	})
+
+	t.Run("Same Source And Destination", func(t *testing.T) {
+		path, cost, err := g.ShortestPath("A", "A")
+		if err != nil {
+			t.Fatalf("ShortestPath returned error: %v", err)
+		}
+		if cost != 0 || !reflect.DeepEqual(path, []string{"A"}) {
+			t.Errorf("Expected trivial path [A] with cost 0, got %v cost %v", path, cost)
+		}
+	})
+
+	t.Run("Disconnected Component", func(t *testing.T) {
+		_, _, err := g.ShortestPath("A", "E")
+		if err == nil {
+			t.Error("Expected error for path to an unreachable node")
+		}
+	})
+
+	t.Run("Unknown Nodes", func(t *testing.T) {
+		if _, _, err := g.ShortestPath("nonexistent", "A"); err == nil {
+			t.Error("Expected error for unknown source node")
+		}
+		if _, _, err := g.ShortestPath("A", "nonexistent"); err == nil {
+			t.Error("Expected error for unknown destination node")
+		}
+	})
+
+	t.Run("Negative Weight Rejected", func(t *testing.T) {
+		g := New()
+		g.AddNode("A", nil)
+		g.AddNode("B", nil)
+		g.AddEdge("A", "B", -1)
+
+		_, _, err := g.ShortestPath("A", "B")
+		if err == nil {
+			t.Fatal("Expected error for negative edge weight")
+		}
+		if _, ok := err.(*ErrNegativeWeight); !ok {
+			t.Errorf("Expected *ErrNegativeWeight, got %T", err)
+		}
+	})
+
+	t.Run("ShortestPathFunc Custom Metric", func(t *testing.T) {
+		// Hop-count metric should prefer A->D directly (1 hop) over A->B->D (2 hops).
+		path, cost, err := g.ShortestPathFunc("A", "D", func(e *Edge) float64 { return 1 })
+		if err != nil {
+			t.Fatalf("ShortestPathFunc returned error: %v", err)
+		}
+		if cost != 1 {
+			t.Errorf("Expected hop-count cost 1, got %v", cost)
+		}
+		if !reflect.DeepEqual(path, []string{"A", "D"}) {
+			t.Errorf("Expected path [A D], got %v", path)
+		}
+	})
+}
+
+func TestAllShortestPaths(t *testing.T) {
+	g := buildRoutingGraph(t)
+
+	dist, prev, err := g.AllShortestPaths("A")
+	if err != nil {
+		t.Fatalf("AllShortestPaths returned error: %v", err)
+	}
+
+	expectedDist := map[string]float64{"A": 0, "B": 1, "C": 1, "D": 2}
+	for id, want := range expectedDist {
+		if got := dist[id]; got != want {
+			t.Errorf("dist[%s] = %v, want %v", id, got, want)
+		}
+	}
+	if _, ok := dist["E"]; ok {
+		t.Error("Expected disconnected node E to be absent from dist")
+	}
+	if prev["D"] != "C" {
+		t.Errorf("Expected D's predecessor to be C, got %s", prev["D"])
+	}
+}
+
+func TestBFS(t *testing.T) {
+	g := buildRoutingGraph(t)
+
+	t.Run("Visits All Reachable Nodes With Depth", func(t *testing.T) {
+		depths := make(map[string]int)
+		err := g.BFS("A", func(id string, depth int) bool {
+			depths[id] = depth
+			return true
+		})
+		if err != nil {
+			t.Fatalf("BFS returned error: %v", err)
+		}
+
+		expected := map[string]int{"A": 0, "B": 1, "C": 1, "D": 1}
+		for id, want := range expected {
+			if got, ok := depths[id]; !ok || got != want {
+				t.Errorf("depth[%s] = %v (ok=%v), want %v", id, got, ok, want)
+			}
+		}
+		if _, ok := depths["E"]; ok {
+			t.Error("Expected disconnected node E not to be visited")
+		}
+	})
+
+	t.Run("Stops Early", func(t *testing.T) {
+		visited := 0
+		err := g.BFS("A", func(id string, depth int) bool {
+			visited++
+			return false
+		})
+		if err != nil {
+			t.Fatalf("BFS returned error: %v", err)
+		}
+		if visited != 1 {
+			t.Errorf("Expected BFS to stop after the first visit, visited %d nodes", visited)
+		}
+	})
+
+	t.Run("Unknown Start Node", func(t *testing.T) {
+		err := g.BFS("nonexistent", func(id string, depth int) bool { return true })
+		if err == nil {
+			t.Error("Expected error for unknown start node")
+		}
+	})
+}
+
+func TestBellmanFord(t *testing.T) {
+	t.Run("Handles Negative Weights", func(t *testing.T) {
+		g := New()
+		for _, id := range []string{"A", "B", "C"} {
+			g.AddNode(id, nil)
+		}
+		g.AddEdge("A", "B", 4)
+		g.AddEdge("A", "C", 1)
+		g.AddEdge("C", "B", -2)
+
+		dist, prev, err := g.BellmanFord("A")
+		if err != nil {
+			t.Fatalf("BellmanFord returned error: %v", err)
+		}
+		if dist["B"] != -1 {
+			t.Errorf("Expected dist[B] = -1, got %v", dist["B"])
+		}
+		if prev["B"] != "C" {
+			t.Errorf("Expected B's predecessor to be C, got %s", prev["B"])
+		}
+	})
+
+	t.Run("Detects Negative Cycle", func(t *testing.T) {
+		g := New()
+		for _, id := range []string{"A", "B", "C"} {
+			g.AddNode(id, nil)
+		}
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("B", "C", -3)
+		g.AddEdge("C", "B", 1)
+
+		_, _, err := g.BellmanFord("A")
+		if err == nil {
+			t.Fatal("Expected error for negative-weight cycle")
+		}
+		if _, ok := err.(*NegativeCycleError); !ok {
+			t.Errorf("Expected *NegativeCycleError, got %T", err)
+		}
+	})
+
+	t.Run("Unknown Start Node", func(t *testing.T) {
+		g := buildRoutingGraph(t)
+		if _, _, err := g.BellmanFord("nonexistent"); err == nil {
+			t.Error("Expected error for unknown start node")
+		}
+	})
+}
+
+func TestAStar(t *testing.T) {
+	zeroHeuristic := func(id string) float64 { return 0 }
+
+	t.Run("Finds Cheapest Path With Zero Heuristic", func(t *testing.T) {
+		g := buildRoutingGraph(t)
+
+		path, cost, err := g.AStar("A", "D", zeroHeuristic)
+		if err != nil {
+			t.Fatalf("AStar returned error: %v", err)
+		}
+		if cost != 2 {
+			t.Errorf("Expected cost 2, got %v", cost)
+		}
+		want := []string{"A", "C", "D"}
+		if !reflect.DeepEqual(path, want) {
+			t.Errorf("Expected path %v, got %v", want, path)
+		}
+	})
+
+	t.Run("Finds Cheapest Path With Admissible Heuristic", func(t *testing.T) {
+		g := buildRoutingGraph(t)
+
+		// An admissible (never overestimating) heuristic that still steers
+		// search toward D.
+		h := map[string]float64{"A": 2, "B": 2, "C": 1, "D": 0, "E": 0}
+		path, cost, err := g.AStar("A", "D", func(id string) float64 { return h[id] })
+		if err != nil {
+			t.Fatalf("AStar returned error: %v", err)
+		}
+		if cost != 2 {
+			t.Errorf("Expected cost 2, got %v", cost)
+		}
+		want := []string{"A", "C", "D"}
+		if !reflect.DeepEqual(path, want) {
+			t.Errorf("Expected path %v, got %v", want, path)
+		}
+	})
+
+	t.Run("Same Source And Destination", func(t *testing.T) {
+		g := buildRoutingGraph(t)
+
+		path, cost, err := g.AStar("A", "A", zeroHeuristic)
+		if err != nil {
+			t.Fatalf("AStar returned error: %v", err)
+		}
+		if cost != 0 || !reflect.DeepEqual(path, []string{"A"}) {
+			t.Errorf("Expected trivial path [A] with cost 0, got %v cost %v", path, cost)
+		}
+	})
+
+	t.Run("Disconnected Destination", func(t *testing.T) {
+		g := buildRoutingGraph(t)
+
+		if _, _, err := g.AStar("A", "E", zeroHeuristic); err == nil {
+			t.Error("Expected error for unreachable destination")
+		}
+	})
+
+	t.Run("Unknown Nodes", func(t *testing.T) {
+		g := buildRoutingGraph(t)
+
+		if _, _, err := g.AStar("nonexistent", "D", zeroHeuristic); err == nil {
+			t.Error("Expected error for unknown source node")
+		}
+		if _, _, err := g.AStar("A", "nonexistent", zeroHeuristic); err == nil {
+			t.Error("Expected error for unknown destination node")
+		}
+	})
+
+	t.Run("Rejects Negative Weights", func(t *testing.T) {
+		g := New()
+		for _, id := range []string{"A", "B"} {
+			g.AddNode(id, nil)
+		}
+		g.AddEdge("A", "B", -1)
+
+		if _, _, err := g.AStar("A", "B", zeroHeuristic); err == nil {
+			t.Error("Expected error for negative edge weight")
+		}
+	})
+}
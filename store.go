@@ -0,0 +1,600 @@
+package gropher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Store is a pluggable persistence backend for a Graph's Nodes and Edges.
+// Graph itself always keeps its working set in memory (g.Nodes/g.Edges);
+// SyncToStore and LoadFromStore use Store to move that working set to and
+// from a backend that can scale beyond RAM, such as NewFileStore's
+// one-file-per-node layout, without changing Graph's in-memory query and
+// algorithm surface.
+type Store interface {
+	// GetNode returns the node with the given id, or an error if it is
+	// not present in the store.
+	GetNode(id string) (*Node, error)
+	// PutNode creates or replaces a node.
+	PutNode(node *Node) error
+	// DeleteNode removes a node and every edge referencing it.
+	DeleteNode(id string) error
+	// PutEdge creates or replaces an edge, keyed by (From, To, Key).
+	PutEdge(edge *Edge) error
+	// DeleteEdge removes the edge identified by (from, to, key).
+	DeleteEdge(from, to, key string) error
+	// IterateEdgesFrom calls cb once for every edge stored with the given
+	// source id. NewFileStore's implementation reads its whole edge index
+	// file on each call and filters it by source. Iteration stops early,
+	// without error, if cb returns ErrStopTraversal.
+	IterateEdgesFrom(from string, cb func(*Edge) error) error
+	// EachNode calls cb once for every node in the store, streamed in
+	// rather than all loaded into memory at once. Iteration stops early,
+	// without error, if cb returns ErrStopTraversal.
+	EachNode(cb func(*Node) error) error
+	// EachEdge calls cb once for every edge in the store, with both
+	// endpoint Nodes resolved. NewFileStore's implementation reads its
+	// whole edge index file at once. Iteration stops early, without error,
+	// if cb returns ErrStopTraversal.
+	EachEdge(cb func(from *Node, e *Edge, to *Node) error) error
+}
+
+// Traverse walks the graph held by s depth-first starting at root, the
+// same way Graph.Traverse does, but fetching each Node and its outgoing
+// Edges from s on demand instead of requiring the whole graph to be
+// loaded into memory first. Either callback may be nil. Cycles are
+// handled via a visited set, so each node is visited at most once.
+// Returning ErrStopTraversal from onNode or onEdge cleanly aborts the
+// walk.
+func Traverse(s Store, root string, onNode NodeCallback, onEdge EdgeCallback) error {
+	if _, err := s.GetNode(root); err != nil {
+		return fmt.Errorf("node with ID %s does not exist", root)
+	}
+
+	visited := make(map[string]bool)
+	if err := storeDFS(s, root, visited, onNode, onEdge); err != nil && err != errStoreTraversalStopped {
+		return err
+	}
+	return nil
+}
+
+// errStoreTraversalStopped is storeDFS's internal stand-in for
+// ErrStopTraversal. IterateEdgesFrom implementations swallow
+// ErrStopTraversal themselves (stopping only that one from-node's
+// iteration), so storeDFS returns this distinct sentinel instead to
+// propagate a stop all the way up through nested IterateEdgesFrom calls;
+// Traverse converts it back to a nil error at the top.
+var errStoreTraversalStopped = errors.New("gropher: store traversal stopped")
+
+// storeDFS is the recursive depth-first walk behind Traverse.
+func storeDFS(s Store, id string, visited map[string]bool, onNode NodeCallback, onEdge EdgeCallback) error {
+	if visited[id] {
+		return nil
+	}
+	visited[id] = true
+
+	node, err := s.GetNode(id)
+	if err != nil {
+		return err
+	}
+	if onNode != nil {
+		if err := onNode(node); err != nil {
+			if err == ErrStopTraversal {
+				return errStoreTraversalStopped
+			}
+			return err
+		}
+	}
+
+	return s.IterateEdgesFrom(id, func(edge *Edge) error {
+		to, err := s.GetNode(edge.To)
+		if err != nil {
+			return err
+		}
+		if onEdge != nil {
+			if err := onEdge(node, edge, to); err != nil {
+				if err == ErrStopTraversal {
+					return errStoreTraversalStopped
+				}
+				return err
+			}
+		}
+		return storeDFS(s, edge.To, visited, onNode, onEdge)
+	})
+}
+
+// SyncToStore writes every Node and Edge currently in g to s. It's the
+// mirror of LoadFromStore, letting an in-memory Graph built during this
+// process be resumed later by a new Graph via LoadFromStore.
+func (g *Graph) SyncToStore(s Store) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, node := range g.Nodes {
+		if err := s.PutNode(node); err != nil {
+			return fmt.Errorf("failed to sync node %s: %v", node.ID, err)
+		}
+	}
+	for _, tos := range g.Edges {
+		for _, parallel := range tos {
+			for _, edge := range parallel {
+				if err := s.PutEdge(edge); err != nil {
+					return fmt.Errorf("failed to sync edge %s->%s: %v", edge.From, edge.To, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// LoadFromStore replaces g's contents with every node and edge in s,
+// streamed out via s's EachNode/IterateEdgesFrom iterators rather than
+// requiring s to return everything at once.
+func (g *Graph) LoadFromStore(s Store) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Nodes = make(map[string]*Node)
+	g.Edges = make(map[string]map[string][]*Edge)
+	g.typeIndex = make(map[string]map[string]*Node)
+
+	if err := s.EachNode(func(node *Node) error {
+		g.Nodes[node.ID] = node
+		g.Edges[node.ID] = make(map[string][]*Edge)
+		g.indexNodeType(node)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to load store nodes: %v", err)
+	}
+
+	for id := range g.Nodes {
+		if err := s.IterateEdgesFrom(id, func(edge *Edge) error {
+			return g.addEdgeLocked(edge)
+		}); err != nil {
+			return fmt.Errorf("failed to load edges from %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// memoryStore is the Store backing NewMemoryStore: a mutex-guarded copy of
+// the same Nodes/Edges shape Graph itself uses.
+type memoryStore struct {
+	mu    sync.RWMutex
+	nodes map[string]*Node
+	edges map[string]map[string][]*Edge
+}
+
+// NewMemoryStore returns a Store backed by in-process maps. It's useful
+// mainly for testing SyncToStore/LoadFromStore without touching disk.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		nodes: make(map[string]*Node),
+		edges: make(map[string]map[string][]*Edge),
+	}
+}
+
+func (s *memoryStore) GetNode(id string) (*Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node, exists := s.nodes[id]
+	if !exists {
+		return nil, fmt.Errorf("node with ID %s does not exist", id)
+	}
+	copied := *node
+	return &copied, nil
+}
+
+func (s *memoryStore) PutNode(node *Node) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *node
+	s.nodes[node.ID] = &copied
+	return nil
+}
+
+func (s *memoryStore) DeleteNode(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.nodes, id)
+	delete(s.edges, id)
+	for _, tos := range s.edges {
+		delete(tos, id)
+	}
+	return nil
+}
+
+func (s *memoryStore) PutEdge(edge *Edge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.edges[edge.From] == nil {
+		s.edges[edge.From] = make(map[string][]*Edge)
+	}
+
+	copied := *edge
+	parallel := s.edges[edge.From][edge.To]
+	for i, existing := range parallel {
+		if existing.Key == edge.Key {
+			parallel[i] = &copied
+			return nil
+		}
+	}
+	s.edges[edge.From][edge.To] = append(parallel, &copied)
+	return nil
+}
+
+func (s *memoryStore) DeleteEdge(from, to, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parallel := s.edges[from][to]
+	for i, existing := range parallel {
+		if existing.Key == key {
+			s.edges[from][to] = append(parallel[:i], parallel[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("edge from %s to %s does not exist", from, to)
+}
+
+func (s *memoryStore) IterateEdgesFrom(from string, cb func(*Edge) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, parallel := range s.edges[from] {
+		for _, edge := range parallel {
+			if err := cb(edge); err != nil {
+				if err == ErrStopTraversal {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) EachNode(cb func(*Node) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, node := range s.nodes {
+		copied := *node
+		if err := cb(&copied); err != nil {
+			if err == ErrStopTraversal {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) EachEdge(cb func(from *Node, e *Edge, to *Node) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for from, tos := range s.edges {
+		for _, parallel := range tos {
+			for _, edge := range parallel {
+				if err := cb(s.nodes[from], edge, s.nodes[edge.To]); err != nil {
+					if err == ErrStopTraversal {
+						return nil
+					}
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fileStore is the Store backing NewFileStore: each node is its own
+// "<type>_<id>.json" file in dir, and edges live together in a single
+// "edges.json" index file.
+type fileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileStore returns a Store that persists each node as its own JSON
+// file under dir (named "<type>_<id>.json") and edges in a single
+// "edges.json" index file in dir, along the lines of bettercap's graph
+// store.
+func NewFileStore(dir string) Store {
+	return &fileStore{dir: dir}
+}
+
+func (s *fileStore) nodeGlob(id string) string {
+	return filepath.Join(s.dir, "*_"+sanitizeFileComponent(id)+".json")
+}
+
+func (s *fileStore) nodePath(node *Node) string {
+	typ := node.Type
+	if typ == "" {
+		typ = "untyped"
+	}
+	return filepath.Join(s.dir, fmt.Sprintf("%s_%s.json", sanitizeFileComponent(typ), sanitizeFileComponent(node.ID)))
+}
+
+func (s *fileStore) edgeIndexPath() string {
+	return filepath.Join(s.dir, "edges.json")
+}
+
+func (s *fileStore) GetNode(id string) (*Node, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches, err := filepath.Glob(s.nodeGlob(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up node %s: %v", id, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("node with ID %s does not exist", id)
+	}
+
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node %s: %v", id, err)
+	}
+	var node Node
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to decode node %s: %v", id, err)
+	}
+	return &node, nil
+}
+
+func (s *fileStore) PutNode(node *Node) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create store directory: %v", err)
+	}
+
+	data, err := json.Marshal(node)
+	if err != nil {
+		return fmt.Errorf("failed to encode node %s: %v", node.ID, err)
+	}
+
+	// A node whose Type changed has its old "<type>_<id>.json" file removed.
+	path := s.nodePath(node)
+	stale, err := filepath.Glob(s.nodeGlob(node.ID))
+	if err != nil {
+		return fmt.Errorf("failed to look up node %s: %v", node.ID, err)
+	}
+	for _, match := range stale {
+		if match != path {
+			if err := os.Remove(match); err != nil {
+				return fmt.Errorf("failed to replace node %s: %v", node.ID, err)
+			}
+		}
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write node %s: %v", node.ID, err)
+	}
+	return nil
+}
+
+func (s *fileStore) DeleteNode(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches, err := filepath.Glob(s.nodeGlob(id))
+	if err != nil {
+		return fmt.Errorf("failed to look up node %s: %v", id, err)
+	}
+	for _, match := range matches {
+		if err := os.Remove(match); err != nil {
+			return fmt.Errorf("failed to delete node %s: %v", id, err)
+		}
+	}
+
+	edges, err := s.readEdgeIndex()
+	if err != nil {
+		return err
+	}
+	kept := edges[:0]
+	for _, edge := range edges {
+		if edge.From != id && edge.To != id {
+			kept = append(kept, edge)
+		}
+	}
+	return s.writeEdgeIndex(kept)
+}
+
+func (s *fileStore) PutEdge(edge *Edge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	edges, err := s.readEdgeIndex()
+	if err != nil {
+		return err
+	}
+	for i, existing := range edges {
+		if existing.From == edge.From && existing.To == edge.To && existing.Key == edge.Key {
+			edges[i] = edge
+			return s.writeEdgeIndex(edges)
+		}
+	}
+	return s.writeEdgeIndex(append(edges, edge))
+}
+
+func (s *fileStore) DeleteEdge(from, to, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	edges, err := s.readEdgeIndex()
+	if err != nil {
+		return err
+	}
+	for i, existing := range edges {
+		if existing.From == from && existing.To == to && existing.Key == key {
+			edges = append(edges[:i], edges[i+1:]...)
+			return s.writeEdgeIndex(edges)
+		}
+	}
+	return fmt.Errorf("edge from %s to %s does not exist", from, to)
+}
+
+func (s *fileStore) IterateEdgesFrom(from string, cb func(*Edge) error) error {
+	s.mu.Lock()
+	edges, err := s.readEdgeIndex()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, edge := range edges {
+		if edge.From != from {
+			continue
+		}
+		if err := cb(edge); err != nil {
+			if err == ErrStopTraversal {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fileStore) EachNode(cb func(*Node) error) error {
+	s.mu.Lock()
+	matches, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to list store directory: %v", err)
+	}
+
+	for _, match := range matches {
+		if filepath.Base(match) == "edges.json" {
+			continue
+		}
+
+		data, err := os.ReadFile(match)
+		if err != nil {
+			return fmt.Errorf("failed to read node file %s: %v", match, err)
+		}
+		var node Node
+		if err := json.Unmarshal(data, &node); err != nil {
+			return fmt.Errorf("failed to decode node file %s: %v", match, err)
+		}
+
+		if err := cb(&node); err != nil {
+			if err == ErrStopTraversal {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// EachEdge calls cb once for every edge in the index file, resolving both
+// endpoint Nodes via GetNode. Unlike EachNode, this does require the whole
+// edge index to be read at once, since fileStore keeps it in a single
+// file; only per-node storage is split out.
+func (s *fileStore) EachEdge(cb func(from *Node, e *Edge, to *Node) error) error {
+	s.mu.Lock()
+	edges, err := s.readEdgeIndex()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, edge := range edges {
+		from, err := s.GetNode(edge.From)
+		if err != nil {
+			return fmt.Errorf("failed to resolve edge source %s: %v", edge.From, err)
+		}
+		to, err := s.GetNode(edge.To)
+		if err != nil {
+			return fmt.Errorf("failed to resolve edge target %s: %v", edge.To, err)
+		}
+		if err := cb(from, edge, to); err != nil {
+			if err == ErrStopTraversal {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// readEdgeIndex reads the store's edge index file. A missing file means no
+// edges have been written yet, which is not an error. Callers must hold
+// s.mu.
+func (s *fileStore) readEdgeIndex() ([]*Edge, error) {
+	data, err := os.ReadFile(s.edgeIndexPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read edge index: %v", err)
+	}
+
+	var edges []*Edge
+	if err := json.Unmarshal(data, &edges); err != nil {
+		return nil, fmt.Errorf("failed to decode edge index: %v", err)
+	}
+	return edges, nil
+}
+
+// writeEdgeIndex overwrites the store's edge index file. Callers must hold
+// s.mu.
+func (s *fileStore) writeEdgeIndex(edges []*Edge) error {
+	data, err := json.Marshal(edges)
+	if err != nil {
+		return fmt.Errorf("failed to encode edge index: %v", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create store directory: %v", err)
+	}
+	if err := os.WriteFile(s.edgeIndexPath(), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write edge index: %v", err)
+	}
+	return nil
+}
+
+// sanitizeFileComponent percent-escapes every "/", "\", ".", "%", and,
+// notably, "_" in s, so it's safe to use as one segment of a file name AND
+// so joining two sanitized components with a literal "_" (as nodePath
+// does for "<type>_<id>.json") never produces an ambiguous boundary.
+// Without escaping "_" itself, Type "foo_bar"/ID "baz" and Type "foo"/ID
+// "bar_baz" would both sanitize to the same "foo_bar_baz.json".
+//
+// It also escapes "*", "?", "[", and "]", the metacharacters filepath.Glob
+// understands, since nodeGlob feeds the result of this function straight
+// into Glob as a literal component. Left unescaped, a node ID like "a*b"
+// would match any node file starting with "a" and ending with "b" instead
+// of just itself, and an ID containing "[" could make Glob return a
+// "syntax error in pattern" instead of looking up the node.
+func sanitizeFileComponent(s string) string {
+	if s == "" {
+		return "_"
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '_', '/', '\\', '.', '%', '*', '?', '[', ']':
+			fmt.Fprintf(&b, "%%%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,259 @@
+package gropher
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func buildStoreGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := New()
+	if err := g.AddTypedNode("a", "person", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedNode("b", "person", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedNode("c", "company", "acme"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge("a", "b", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdgeKeyed("a", "c", "employedBy", 2, nil); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func testStoreRoundTrip(t *testing.T, s Store) {
+	t.Helper()
+	g := buildStoreGraph(t)
+
+	if err := g.SyncToStore(s); err != nil {
+		t.Fatalf("SyncToStore returned error: %v", err)
+	}
+
+	loaded := New()
+	if err := loaded.LoadFromStore(s); err != nil {
+		t.Fatalf("LoadFromStore returned error: %v", err)
+	}
+
+	if len(loaded.Nodes) != len(g.Nodes) {
+		t.Fatalf("Expected %d nodes after round trip, got %d", len(g.Nodes), len(loaded.Nodes))
+	}
+	if people := loaded.NodesByType("person"); len(people) != 2 {
+		t.Errorf("Expected 2 person nodes after round trip, got %d", len(people))
+	}
+
+	edges, err := loaded.GetEdges("a", "b")
+	if err != nil || len(edges) != 1 {
+		t.Errorf("Expected edge a->b to survive the round trip, got %v, %v", edges, err)
+	}
+	edges, err = loaded.GetEdges("a", "c")
+	if err != nil || len(edges) != 1 || edges[0].Key != "employedBy" {
+		t.Errorf("Expected keyed edge a->c to survive the round trip, got %v, %v", edges, err)
+	}
+}
+
+func TestMemoryStoreRoundTrip(t *testing.T) {
+	testStoreRoundTrip(t, NewMemoryStore())
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	testStoreRoundTrip(t, NewFileStore(t.TempDir()))
+}
+
+func TestFileStoreOneFilePerNode(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFileStore(dir)
+	g := buildStoreGraph(t)
+	if err := g.SyncToStore(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"person_a.json", "person_b.json", "company_c.json", "edges.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, want))
+		if err != nil || len(matches) != 1 {
+			t.Errorf("Expected exactly one %s in store dir, got %v, %v", want, matches, err)
+		}
+	}
+}
+
+func TestFileStoreDoesNotCollideOnUnderscoreBoundary(t *testing.T) {
+	s := NewFileStore(t.TempDir())
+
+	if err := s.PutNode(&Node{ID: "baz", Type: "foo_bar", Data: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutNode(&Node{ID: "bar_baz", Type: "foo", Data: "second"}); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := s.GetNode("baz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Data != "first" {
+		t.Errorf("Expected node baz to keep its own data, got %v", first.Data)
+	}
+
+	second, err := s.GetNode("bar_baz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Data != "second" {
+		t.Errorf("Expected node bar_baz to keep its own data, got %v", second.Data)
+	}
+}
+
+func TestFileStoreDoesNotTreatIDAsGlobPattern(t *testing.T) {
+	s := NewFileStore(t.TempDir())
+
+	if err := s.PutNode(&Node{ID: "axbb", Data: "wrong"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutNode(&Node{ID: "a*b", Data: "right"}); err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := s.GetNode("a*b")
+	if err != nil {
+		t.Fatalf("GetNode(a*b) returned error: %v", err)
+	}
+	if node.Data != "right" {
+		t.Errorf("Expected GetNode(a*b) to return its own node, got %v", node.Data)
+	}
+
+	if err := s.PutNode(&Node{ID: "a[b", Data: "bracket"}); err != nil {
+		t.Fatal(err)
+	}
+	node, err = s.GetNode("a[b")
+	if err != nil {
+		t.Fatalf("GetNode(a[b) returned error: %v", err)
+	}
+	if node.Data != "bracket" {
+		t.Errorf("Expected GetNode(a[b) to return its own node, got %v", node.Data)
+	}
+}
+
+func testStoreEachNode(t *testing.T, s Store) {
+	t.Helper()
+	g := buildStoreGraph(t)
+	if err := g.SyncToStore(s); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool)
+	if err := s.EachNode(func(n *Node) error {
+		seen[n.ID] = true
+		return nil
+	}); err != nil {
+		t.Fatalf("EachNode returned error: %v", err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if !seen[id] {
+			t.Errorf("Expected EachNode to visit %s", id)
+		}
+	}
+
+	visited := 0
+	if err := s.EachNode(func(n *Node) error {
+		visited++
+		return ErrStopTraversal
+	}); err != nil {
+		t.Fatalf("Expected ErrStopTraversal to be swallowed, got %v", err)
+	}
+	if visited != 1 {
+		t.Errorf("Expected exactly 1 visit before stopping, got %d", visited)
+	}
+}
+
+func TestMemoryStoreEachNode(t *testing.T) { testStoreEachNode(t, NewMemoryStore()) }
+func TestFileStoreEachNode(t *testing.T)   { testStoreEachNode(t, NewFileStore(t.TempDir())) }
+
+func testStoreEachEdge(t *testing.T, s Store) {
+	t.Helper()
+	g := buildStoreGraph(t)
+	if err := g.SyncToStore(s); err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	err := s.EachEdge(func(from *Node, e *Edge, to *Node) error {
+		count++
+		if from == nil || to == nil {
+			t.Errorf("Expected EachEdge to resolve both endpoints, got from=%v to=%v", from, to)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachEdge returned error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 edges, got %d", count)
+	}
+}
+
+func TestMemoryStoreEachEdge(t *testing.T) { testStoreEachEdge(t, NewMemoryStore()) }
+func TestFileStoreEachEdge(t *testing.T)   { testStoreEachEdge(t, NewFileStore(t.TempDir())) }
+
+func testStoreTraverse(t *testing.T, s Store) {
+	t.Helper()
+	g := buildStoreGraph(t)
+	if err := g.SyncToStore(s); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Visits Every Reachable Node", func(t *testing.T) {
+		var order []string
+		err := Traverse(s, "a", func(n *Node) error {
+			order = append(order, n.ID)
+			return nil
+		}, nil)
+		if err != nil {
+			t.Fatalf("Traverse returned error: %v", err)
+		}
+		seen := make(map[string]int)
+		for _, id := range order {
+			seen[id]++
+		}
+		for _, id := range []string{"a", "b", "c"} {
+			if seen[id] != 1 {
+				t.Errorf("Expected %s to be visited exactly once, got %d", id, seen[id])
+			}
+		}
+	})
+
+	t.Run("Stops On Sentinel Error", func(t *testing.T) {
+		visited := 0
+		err := Traverse(s, "a", func(n *Node) error {
+			visited++
+			return ErrStopTraversal
+		}, nil)
+		if err != nil {
+			t.Fatalf("Expected ErrStopTraversal to be swallowed, got %v", err)
+		}
+		if visited != 1 {
+			t.Errorf("Expected traversal to stop after the first node, got %d visits", visited)
+		}
+	})
+
+	t.Run("Propagates Other Errors", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := Traverse(s, "a", func(n *Node) error { return boom }, nil)
+		if err != boom {
+			t.Errorf("Expected Traverse to propagate non-sentinel errors, got %v", err)
+		}
+	})
+
+	t.Run("Unknown Root Errors", func(t *testing.T) {
+		if err := Traverse(s, "nonexistent", nil, nil); err == nil {
+			t.Error("Expected error for unknown root node")
+		}
+	})
+}
+
+func TestMemoryStoreTraverse(t *testing.T) { testStoreTraverse(t, NewMemoryStore()) }
+func TestFileStoreTraverse(t *testing.T)   { testStoreTraverse(t, NewFileStore(t.TempDir())) }
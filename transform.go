@@ -0,0 +1,240 @@
+package gropher
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Transformer mutates a Graph in place as one stage of a Pipeline.
+// Transform runs while the Graph's write lock is already held, so
+// implementations must work directly with g's fields (or the *Locked
+// helpers such as addEdgeLocked/removeNodeLocked) rather than exported
+// methods like AddEdge/RemoveNode, which would deadlock.
+type Transformer interface {
+	Transform(g *Graph) error
+}
+
+// TransformerFunc adapts a plain function to the Transformer interface.
+type TransformerFunc func(g *Graph) error
+
+// Transform calls f(g).
+func (f TransformerFunc) Transform(g *Graph) error {
+	return f(g)
+}
+
+// Pipeline runs a sequence of Transformers against a Graph under a single
+// write lock, so other goroutines never observe an intermediate state and
+// each stage sees the previous stage's result.
+type Pipeline struct {
+	Stages []Transformer
+}
+
+// NewPipeline creates a Pipeline that runs stages in order.
+func NewPipeline(stages ...Transformer) *Pipeline {
+	return &Pipeline{Stages: stages}
+}
+
+// Run executes the Pipeline's stages against g in order, stopping at the
+// first error.
+func (p *Pipeline) Run(g *Graph) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for i, stage := range p.Stages {
+		if err := stage.Transform(g); err != nil {
+			return fmt.Errorf("pipeline stage %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// PruneIsolated is a Transformer that removes every node with neither
+// incoming nor outgoing Edges.
+var PruneIsolated Transformer = TransformerFunc(func(g *Graph) error {
+	connected := make(map[string]bool)
+	for from, edges := range g.Edges {
+		for to, parallel := range edges {
+			if len(parallel) == 0 {
+				continue
+			}
+			connected[from] = true
+			connected[to] = true
+		}
+	}
+
+	for id := range g.Nodes {
+		if !connected[id] {
+			g.removeNodeLocked(id)
+		}
+	}
+	return nil
+})
+
+// PruneByPredicate returns a Transformer that removes every node for which
+// remove returns true, along with its Edges.
+func PruneByPredicate(remove func(*Node) bool) Transformer {
+	return TransformerFunc(func(g *Graph) error {
+		for id, node := range g.Nodes {
+			if remove(node) {
+				g.removeNodeLocked(id)
+			}
+		}
+		return nil
+	})
+}
+
+// MergeNodes returns a Transformer that deduplicates Nodes: for every pair
+// for which same reports true, it replaces the pair with merge(a, b),
+// keeping the lexicographically first id of the pair and rewiring every
+// edge that referenced either original node to point at it instead. Nodes
+// are compared in id order, so a chain of mutually-same Nodes collapses
+// into one.
+func MergeNodes(same func(a, b *Node) bool, merge func(a, b *Node) *Node) Transformer {
+	return TransformerFunc(func(g *Graph) error {
+		ids := make([]string, 0, len(g.Nodes))
+		for id := range g.Nodes {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for i := 0; i < len(ids); i++ {
+			keepID := ids[i]
+			keep, exists := g.Nodes[keepID]
+			if !exists {
+				continue
+			}
+
+			for j := i + 1; j < len(ids); j++ {
+				dropID := ids[j]
+				drop, exists := g.Nodes[dropID]
+				if !exists || !same(keep, drop) {
+					continue
+				}
+
+				combined := merge(keep, drop)
+				combined.ID = keepID
+
+				g.rewireNodeLocked(dropID, keepID)
+				g.unindexNodeType(keep)
+				g.Nodes[keepID] = combined
+				g.indexNodeType(combined)
+				keep = combined
+			}
+		}
+		return nil
+	})
+}
+
+// ContractEdge returns a Transformer that collapses the edge from "from"
+// to "to" by folding "to" into "from": every edge that referenced "to" is
+// rewired to reference "from" instead, and "to" is then removed.
+func ContractEdge(from, to string) Transformer {
+	return TransformerFunc(func(g *Graph) error {
+		if from == to {
+			return fmt.Errorf("cannot contract node %s with itself", from)
+		}
+		if _, exists := g.Nodes[from]; !exists {
+			return fmt.Errorf("node with ID %s does not exist", from)
+		}
+		if _, exists := g.Nodes[to]; !exists {
+			return fmt.Errorf("node with ID %s does not exist", to)
+		}
+
+		g.rewireNodeLocked(to, from)
+		return nil
+	})
+}
+
+// Transpose is a Transformer that reverses the direction of every edge in
+// the graph, turning each from->to into to->from.
+var Transpose Transformer = TransformerFunc(func(g *Graph) error {
+	reversed := make(map[string]map[string][]*Edge, len(g.Edges))
+	for id := range g.Nodes {
+		reversed[id] = make(map[string][]*Edge)
+	}
+
+	for from, edges := range g.Edges {
+		for to, parallel := range edges {
+			for _, edge := range parallel {
+				flipped := *edge
+				flipped.From, flipped.To = to, from
+				reversed[to][from] = append(reversed[to][from], &flipped)
+			}
+		}
+	}
+
+	g.Edges = reversed
+	return nil
+})
+
+// InduceSubgraph returns a new Graph containing a copy of every node for
+// which keep returns true, plus every edge whose endpoints both survive.
+// g itself is left unmodified.
+func (g *Graph) InduceSubgraph(keep func(*Node) bool) *Graph {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.induceSubgraphLocked(keep)
+}
+
+// induceSubgraphLocked does the work of InduceSubgraph. Callers must hold
+// g.mu for reading (or writing).
+func (g *Graph) induceSubgraphLocked(keep func(*Node) bool) *Graph {
+	sub := New()
+	for id, node := range g.Nodes {
+		if !keep(node) {
+			continue
+		}
+		copied := *node
+		sub.Nodes[id] = &copied
+		sub.Edges[id] = make(map[string][]*Edge)
+		sub.indexNodeType(&copied)
+	}
+
+	for from, edges := range g.Edges {
+		if _, ok := sub.Nodes[from]; !ok {
+			continue
+		}
+		for to, parallel := range edges {
+			if _, ok := sub.Nodes[to]; !ok {
+				continue
+			}
+			for _, edge := range parallel {
+				copied := *edge
+				sub.Edges[from][to] = append(sub.Edges[from][to], &copied)
+			}
+		}
+	}
+
+	return sub
+}
+
+// rewireNodeLocked redirects every edge referencing oldID to newID instead,
+// then removes oldID. Edges directly between oldID and newID are dropped
+// rather than turned into self-loops. Callers must hold g.mu for writing
+// and ensure oldID and newID both exist and differ.
+func (g *Graph) rewireNodeLocked(oldID, newID string) {
+	for to, parallel := range g.Edges[oldID] {
+		if to == newID || to == oldID {
+			continue
+		}
+		for _, edge := range parallel {
+			rewired := *edge
+			rewired.From = newID
+			g.addEdgeLocked(&rewired)
+		}
+	}
+
+	for from, edges := range g.Edges {
+		if from == oldID || from == newID {
+			continue
+		}
+		for _, edge := range edges[oldID] {
+			rewired := *edge
+			rewired.To = newID
+			g.addEdgeLocked(&rewired)
+		}
+	}
+
+	g.removeNodeLocked(oldID)
+}
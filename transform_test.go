@@ -0,0 +1,227 @@
+package gropher
+
+import "testing"
+
+func buildTransformGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := New()
+	for _, id := range []string{"a", "b", "c", "lonely"} {
+		if err := g.AddNode(id, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.AddEdge("a", "b", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge("b", "c", 2); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestPruneIsolated(t *testing.T) {
+	g := buildTransformGraph(t)
+
+	if err := NewPipeline(PruneIsolated).Run(g); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if _, err := g.GetNode("lonely"); err == nil {
+		t.Error("Expected isolated node to be pruned")
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := g.GetNode(id); err != nil {
+			t.Errorf("Expected connected node %s to survive, got %v", id, err)
+		}
+	}
+}
+
+func TestPruneByPredicate(t *testing.T) {
+	g := buildTransformGraph(t)
+
+	err := NewPipeline(PruneByPredicate(func(n *Node) bool {
+		return n.ID == "c"
+	})).Run(g)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if _, err := g.GetNode("c"); err == nil {
+		t.Error("Expected node c to be pruned")
+	}
+	if edges, _ := g.GetEdges("b", "c"); edges != nil {
+		t.Error("Expected edge b->c to be removed along with c")
+	}
+}
+
+func TestMergeNodes(t *testing.T) {
+	g := New()
+	for _, id := range []string{"x1", "x2", "y"} {
+		if err := g.AddTypedNode(id, "thing", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.AddEdge("x1", "y", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge("y", "x2", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	same := func(a, b *Node) bool {
+		return a.Type == "thing" && b.Type == "thing" &&
+			(a.ID == "x1" || a.ID == "x2") && (b.ID == "x1" || b.ID == "x2")
+	}
+	merge := func(a, b *Node) *Node {
+		return &Node{Type: "thing", Data: "merged"}
+	}
+
+	if err := NewPipeline(MergeNodes(same, merge)).Run(g); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if _, err := g.GetNode("x2"); err == nil {
+		t.Error("Expected x2 to be merged away")
+	}
+	merged, err := g.GetNode("x1")
+	if err != nil {
+		t.Fatalf("Expected merged node to survive at x1: %v", err)
+	}
+	if merged.Data != "merged" {
+		t.Errorf("Expected merged node's Data to be 'merged', got %v", merged.Data)
+	}
+
+	neighbors, err := g.GetNeighbors("y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(neighbors) != 1 || neighbors[0].ID != "x1" {
+		t.Errorf("Expected y's only neighbor to be merged node x1, got %v", neighbors)
+	}
+}
+
+func TestContractEdge(t *testing.T) {
+	g := New()
+	for _, id := range []string{"a", "b", "c"} {
+		if err := g.AddNode(id, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.AddEdge("a", "b", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge("b", "c", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewPipeline(ContractEdge("a", "b")).Run(g); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if _, err := g.GetNode("b"); err == nil {
+		t.Error("Expected b to be removed after contracting into a")
+	}
+	edges, err := g.GetEdges("a", "c")
+	if err != nil {
+		t.Fatalf("Expected a->c edge after contraction: %v", err)
+	}
+	if len(edges) != 1 || edges[0].Weight != 2 {
+		t.Errorf("Expected contracted edge a->c to carry the original weight 2, got %+v", edges)
+	}
+
+	t.Run("Unknown Node Errors", func(t *testing.T) {
+		if err := NewPipeline(ContractEdge("a", "nonexistent")).Run(g); err == nil {
+			t.Error("Expected error contracting an unknown node")
+		}
+	})
+
+	t.Run("Self Contraction Errors", func(t *testing.T) {
+		if err := NewPipeline(ContractEdge("a", "a")).Run(g); err == nil {
+			t.Error("Expected error contracting a node with itself")
+		}
+	})
+}
+
+func TestTranspose(t *testing.T) {
+	g := buildTransformGraph(t)
+
+	if err := NewPipeline(Transpose).Run(g); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if _, err := g.GetEdges("b", "a"); err != nil {
+		t.Errorf("Expected reversed edge b->a: %v", err)
+	}
+	if _, err := g.GetEdges("a", "b"); err == nil {
+		t.Error("Expected original edge a->b to no longer exist")
+	}
+}
+
+func TestTransposePreservesKindAndCascadeFlags(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+	g.AddNode("b", nil)
+	if err := g.AddTypedEdge("a", "b", "contains", 1, EdgeOptions{CascadeToTarget: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewPipeline(Transpose).Run(g); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	edges, err := g.GetEdges("b", "a")
+	if err != nil {
+		t.Fatalf("Expected reversed edge b->a: %v", err)
+	}
+	if len(edges) != 1 {
+		t.Fatalf("Expected exactly 1 reversed edge, got %d", len(edges))
+	}
+	if edges[0].Kind != "contains" {
+		t.Errorf("Expected Transpose to preserve Kind, got %q", edges[0].Kind)
+	}
+	if !edges[0].CascadeToTarget {
+		t.Error("Expected Transpose to preserve CascadeToTarget")
+	}
+}
+
+func TestInduceSubgraph(t *testing.T) {
+	g := buildTransformGraph(t)
+
+	sub := g.InduceSubgraph(func(n *Node) bool {
+		return n.ID != "lonely" && n.ID != "c"
+	})
+
+	if _, err := sub.GetNode("c"); err == nil {
+		t.Error("Expected c to be excluded from the induced subgraph")
+	}
+	if _, err := sub.GetEdges("a", "b"); err != nil {
+		t.Errorf("Expected a->b to survive in the induced subgraph: %v", err)
+	}
+	if _, err := sub.GetEdges("b", "c"); err == nil {
+		t.Error("Expected b->c to be dropped since c was excluded")
+	}
+
+	// g itself must be unmodified.
+	if _, err := g.GetNode("c"); err != nil {
+		t.Errorf("Expected original graph to still have node c: %v", err)
+	}
+}
+
+func TestPipelineComposesMultiplePasses(t *testing.T) {
+	g := buildTransformGraph(t)
+
+	pipeline := NewPipeline(PruneIsolated, Transpose, ContractEdge("c", "b"))
+	if err := pipeline.Run(g); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if _, err := g.GetNode("lonely"); err == nil {
+		t.Error("Expected lonely node pruned by the first stage")
+	}
+	if _, err := g.GetNode("b"); err == nil {
+		t.Error("Expected b contracted into c by the third stage")
+	}
+	if _, err := g.GetEdges("c", "a"); err != nil {
+		t.Errorf("Expected transposed+contracted edge c->a: %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package gropher
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrStopTraversal is a sentinel a NodeCallback or EdgeCallback can return
+// to cleanly abort EachNode, EachEdge, or Traverse without the caller
+// seeing it as a failure.
+var ErrStopTraversal = errors.New("gropher: traversal stopped")
+
+// NodeCallback is invoked once per node visited by Traverse.
+type NodeCallback func(*Node) error
+
+// EdgeCallback is invoked once per edge followed by Traverse, with the
+// Nodes on either end already resolved.
+type EdgeCallback func(from *Node, e *Edge, to *Node) error
+
+// EachNode calls fn once for every node in the graph. Iteration stops
+// early, without error, if fn returns ErrStopTraversal.
+func (g *Graph) EachNode(fn func(*Node) error) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, node := range g.Nodes {
+		if err := fn(node); err != nil {
+			if err == ErrStopTraversal {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// EachEdge calls fn once for every edge in the graph, including parallel
+// edges, with both endpoint Nodes resolved. Iteration stops early, without
+// error, if fn returns ErrStopTraversal.
+func (g *Graph) EachEdge(fn func(from *Node, e *Edge, to *Node) error) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for from, edges := range g.Edges {
+		for to, parallel := range edges {
+			for _, edge := range parallel {
+				if err := fn(g.Nodes[from], edge, g.Nodes[to]); err != nil {
+					if err == ErrStopTraversal {
+						return nil
+					}
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Traverse walks the graph depth-first starting at root, calling onNode
+// the first time each node is visited and onEdge for every edge followed
+// out of it. Either callback may be nil. Cycles are handled via a visited
+// set, so each node is visited at most once. Returning ErrStopTraversal
+// from onNode or onEdge cleanly aborts the walk.
+func (g *Graph) Traverse(root string, onNode NodeCallback, onEdge EdgeCallback) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if _, exists := g.Nodes[root]; !exists {
+		return fmt.Errorf("node with ID %s does not exist", root)
+	}
+
+	visited := make(map[string]bool)
+	if err := g.dfs(root, visited, onNode, onEdge); err != nil && err != ErrStopTraversal {
+		return err
+	}
+	return nil
+}
+
+// dfs is the recursive depth-first walk behind Traverse. The caller must
+// hold at least a read lock on g.
+func (g *Graph) dfs(id string, visited map[string]bool, onNode NodeCallback, onEdge EdgeCallback) error {
+	if visited[id] {
+		return nil
+	}
+	visited[id] = true
+
+	if onNode != nil {
+		if err := onNode(g.Nodes[id]); err != nil {
+			return err
+		}
+	}
+
+	for to, parallel := range g.Edges[id] {
+		for _, edge := range parallel {
+			if onEdge != nil {
+				if err := onEdge(g.Nodes[id], edge, g.Nodes[to]); err != nil {
+					return err
+				}
+			}
+			if err := g.dfs(to, visited, onNode, onEdge); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package gropher
+
+import (
+	"errors"
+	"testing"
+)
+
+func buildTraversalGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := New()
+	if err := g.AddTypedNode("a", "person", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedNode("b", "person", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedNode("c", "company", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddNode("d", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	edges := []struct {
+		from, to string
+	}{
+		{"a", "b"},
+		{"b", "c"},
+		{"c", "a"}, // cycle
+		{"a", "d"},
+	}
+	for _, e := range edges {
+		if err := g.AddEdge(e.from, e.to, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestNodesByType(t *testing.T) {
+	g := buildTraversalGraph(t)
+
+	people := g.NodesByType("person")
+	if len(people) != 2 {
+		t.Errorf("Expected 2 person nodes, got %d", len(people))
+	}
+
+	companies := g.NodesByType("company")
+	if len(companies) != 1 {
+		t.Errorf("Expected 1 company node, got %d", len(companies))
+	}
+
+	if got := g.NodesByType("nonexistent"); len(got) != 0 {
+		t.Errorf("Expected 0 nodes for an unused type, got %d", len(got))
+	}
+
+	t.Run("RemoveNode Updates The Index", func(t *testing.T) {
+		if err := g.RemoveNode("a"); err != nil {
+			t.Fatal(err)
+		}
+		if got := g.NodesByType("person"); len(got) != 1 {
+			t.Errorf("Expected 1 person node after removal, got %d", len(got))
+		}
+	})
+}
+
+func TestEachNode(t *testing.T) {
+	g := buildTraversalGraph(t)
+
+	t.Run("Visits Every Node", func(t *testing.T) {
+		seen := make(map[string]bool)
+		err := g.EachNode(func(n *Node) error {
+			seen[n.ID] = true
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("EachNode returned error: %v", err)
+		}
+		for _, id := range []string{"a", "b", "c", "d"} {
+			if !seen[id] {
+				t.Errorf("Expected EachNode to visit %s", id)
+			}
+		}
+	})
+
+	t.Run("Stops On Sentinel Error", func(t *testing.T) {
+		visited := 0
+		err := g.EachNode(func(n *Node) error {
+			visited++
+			return ErrStopTraversal
+		})
+		if err != nil {
+			t.Fatalf("Expected ErrStopTraversal to be swallowed, got %v", err)
+		}
+		if visited != 1 {
+			t.Errorf("Expected exactly 1 visit before stopping, got %d", visited)
+		}
+	})
+
+	t.Run("Propagates Other Errors", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := g.EachNode(func(n *Node) error { return boom })
+		if err != boom {
+			t.Errorf("Expected EachNode to propagate non-sentinel errors, got %v", err)
+		}
+	})
+}
+
+func TestEachEdge(t *testing.T) {
+	g := buildTraversalGraph(t)
+
+	count := 0
+	err := g.EachEdge(func(from *Node, e *Edge, to *Node) error {
+		count++
+		if from == nil || to == nil {
+			t.Errorf("Expected EachEdge to resolve both endpoints, got from=%v to=%v", from, to)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachEdge returned error: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected 4 edges, got %d", count)
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	g := buildTraversalGraph(t)
+
+	t.Run("DFS Visits Each Reachable Node Once Despite Cycle", func(t *testing.T) {
+		var order []string
+		err := g.Traverse("a", func(n *Node) error {
+			order = append(order, n.ID)
+			return nil
+		}, nil)
+		if err != nil {
+			t.Fatalf("Traverse returned error: %v", err)
+		}
+
+		seen := make(map[string]int)
+		for _, id := range order {
+			seen[id]++
+		}
+		for _, id := range []string{"a", "b", "c", "d"} {
+			if seen[id] != 1 {
+				t.Errorf("Expected %s to be visited exactly once, got %d", id, seen[id])
+			}
+		}
+	})
+
+	t.Run("Edge Callback Sees Every Traversed Edge", func(t *testing.T) {
+		edgeCount := 0
+		err := g.Traverse("a", nil, func(from *Node, e *Edge, to *Node) error {
+			edgeCount++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Traverse returned error: %v", err)
+		}
+		if edgeCount == 0 {
+			t.Error("Expected at least one edge to be visited")
+		}
+	})
+
+	t.Run("Stops On Sentinel Error", func(t *testing.T) {
+		visited := 0
+		err := g.Traverse("a", func(n *Node) error {
+			visited++
+			return ErrStopTraversal
+		}, nil)
+		if err != nil {
+			t.Fatalf("Expected ErrStopTraversal to be swallowed, got %v", err)
+		}
+		if visited != 1 {
+			t.Errorf("Expected traversal to stop after the first node, got %d visits", visited)
+		}
+	})
+
+	t.Run("Unknown Root Errors", func(t *testing.T) {
+		if err := g.Traverse("nonexistent", nil, nil); err == nil {
+			t.Error("Expected error for unknown root node")
+		}
+	})
+}
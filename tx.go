@@ -0,0 +1,241 @@
+package gropher
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tx buffers a batch of mutations against a Graph so they can be applied
+// atomically under a single write lock on Commit, or discarded with
+// Rollback. This is the fix for importing a large edge set via repeated
+// AddEdge calls under g.mu: N lock cycles and a graph left
+// partially-mutated if the Nth call hits a missing node. Tx instead applies
+// the whole batch to a private working copy of the graph first; only if
+// every op succeeds there (cascades from an earlier RemoveNode included) is
+// g's state replaced with the result, so a batch that fails partway never
+// leaves g partially mutated.
+//
+// A Tx is not safe for concurrent use; build it up from a single
+// goroutine and Commit or Rollback it before handing the Graph to
+// another writer.
+type Tx struct {
+	g   *Graph
+	ops []txOp
+}
+
+// txOpKind identifies which Graph mutation a buffered txOp replays.
+type txOpKind int
+
+const (
+	txAddNode txOpKind = iota
+	txRemoveNode
+	txAddEdge
+	txRemoveEdge
+	txAddTypedEdge
+	txRemoveTypedEdge
+)
+
+// txOp is one buffered mutation. Only the fields relevant to kind are set.
+type txOp struct {
+	kind txOpKind
+
+	id   string
+	typ  string
+	data interface{}
+
+	from, to, key, edgeKind string
+	weight                  float64
+	opts                    EdgeOptions
+}
+
+// describe renders op for inclusion in a TxError.
+func (op txOp) describe() string {
+	switch op.kind {
+	case txAddNode:
+		return fmt.Sprintf("AddNode(%s)", op.id)
+	case txRemoveNode:
+		return fmt.Sprintf("RemoveNode(%s)", op.id)
+	case txAddEdge:
+		return fmt.Sprintf("AddEdge(%s, %s)", op.from, op.to)
+	case txRemoveEdge:
+		return fmt.Sprintf("RemoveEdge(%s, %s)", op.from, op.to)
+	case txAddTypedEdge:
+		return fmt.Sprintf("AddTypedEdge(%s, %s, %s)", op.from, op.to, op.edgeKind)
+	case txRemoveTypedEdge:
+		return fmt.Sprintf("RemoveTypedEdge(%s, %s)", op.from, op.to)
+	default:
+		return "unknown op"
+	}
+}
+
+// Begin starts a new transaction against g. The returned Tx buffers
+// mutations until Commit or Rollback is called.
+func (g *Graph) Begin() *Tx {
+	return &Tx{g: g}
+}
+
+// AddNode buffers an untyped AddNode call.
+func (tx *Tx) AddNode(id string, data interface{}) *Tx {
+	return tx.AddTypedNode(id, "", data)
+}
+
+// AddTypedNode buffers an AddTypedNode call.
+func (tx *Tx) AddTypedNode(id, typ string, data interface{}) *Tx {
+	tx.ops = append(tx.ops, txOp{kind: txAddNode, id: id, typ: typ, data: data})
+	return tx
+}
+
+// RemoveNode buffers a RemoveNode call. Cascade-delete behavior is applied
+// the same way as a direct RemoveNode call, at Commit time.
+func (tx *Tx) RemoveNode(id string) *Tx {
+	tx.ops = append(tx.ops, txOp{kind: txRemoveNode, id: id})
+	return tx
+}
+
+// AddEdge buffers an AddEdge call.
+func (tx *Tx) AddEdge(from, to string, weight float64) *Tx {
+	return tx.AddEdgeKeyed(from, to, "", weight, nil)
+}
+
+// AddEdgeKeyed buffers an AddEdgeKeyed call.
+func (tx *Tx) AddEdgeKeyed(from, to, key string, weight float64, data interface{}) *Tx {
+	tx.ops = append(tx.ops, txOp{kind: txAddEdge, from: from, to: to, key: key, weight: weight, data: data})
+	return tx
+}
+
+// RemoveEdge buffers a RemoveEdge call.
+func (tx *Tx) RemoveEdge(from, to string) *Tx {
+	return tx.RemoveEdgeKeyed(from, to, "")
+}
+
+// RemoveEdgeKeyed buffers a RemoveEdgeKeyed call.
+func (tx *Tx) RemoveEdgeKeyed(from, to, key string) *Tx {
+	tx.ops = append(tx.ops, txOp{kind: txRemoveEdge, from: from, to: to, key: key})
+	return tx
+}
+
+// AddTypedEdge buffers an AddTypedEdge call.
+func (tx *Tx) AddTypedEdge(from, to, kind string, weight float64, opts EdgeOptions) *Tx {
+	tx.ops = append(tx.ops, txOp{kind: txAddTypedEdge, from: from, to: to, edgeKind: kind, weight: weight, opts: opts})
+	return tx
+}
+
+// RemoveTypedEdge buffers a RemoveTypedEdge call.
+func (tx *Tx) RemoveTypedEdge(from, to, key string) *Tx {
+	tx.ops = append(tx.ops, txOp{kind: txRemoveTypedEdge, from: from, to: to, key: key})
+	return tx
+}
+
+// Rollback discards every buffered op without touching the Graph.
+func (tx *Tx) Rollback() {
+	tx.ops = nil
+}
+
+// Snapshot returns a copy of the Graph as it stands right now, ignoring any
+// ops buffered on tx but not yet committed. Every Node and Edge is copied,
+// but their Data values are shared with the live Graph. Build complex
+// updates against the returned Graph's stable view, then translate the
+// decisions into calls on tx itself; the snapshot is never mutated by
+// Commit.
+func (tx *Tx) Snapshot() *Graph {
+	return tx.g.InduceSubgraph(func(*Node) bool { return true })
+}
+
+// Commit applies tx's buffered ops to a private working copy of the Graph,
+// one after another, exactly as a direct call would apply them — including
+// RemoveNode's cascades, which can mean a later op's node or edge no longer
+// exists once an earlier op has run. If every op applies cleanly, g's state
+// is swapped for the working copy's under a single write lock, so other
+// readers/writers never observe a partial batch. If any op fails, g is left
+// completely untouched and Commit returns a *TxError listing every
+// offending op (by its original index in the batch, not a re-numbered
+// index into just the failures); on either outcome, the Tx's buffer is
+// cleared so it can be reused for a further batch.
+func (tx *Tx) Commit() error {
+	g := tx.g
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	working := g.induceSubgraphLocked(func(*Node) bool { return true })
+
+	var txErr TxError
+	for i, op := range tx.ops {
+		if err := applyTxOpLocked(working, op); err != nil {
+			txErr.Ops = append(txErr.Ops, TxOpError{Index: i, Op: op.describe(), Reason: err.Error()})
+		}
+	}
+
+	tx.ops = nil
+	if len(txErr.Ops) > 0 {
+		return &txErr
+	}
+
+	g.Nodes = working.Nodes
+	g.Edges = working.Edges
+	g.typeIndex = working.typeIndex
+	return nil
+}
+
+// applyTxOpLocked applies op to g, the same way the corresponding direct
+// Graph method would. Callers must hold g.mu for writing.
+func applyTxOpLocked(g *Graph, op txOp) error {
+	switch op.kind {
+	case txAddNode:
+		return g.addNodeLocked(op.id, op.typ, op.data)
+	case txRemoveNode:
+		if _, exists := g.Nodes[op.id]; !exists {
+			return fmt.Errorf("node with ID %s does not exist", op.id)
+		}
+		g.cascadeRemoveNodeLocked(op.id, make(map[string]bool))
+		return nil
+	case txAddEdge:
+		return g.addEdgeLocked(&Edge{From: op.from, To: op.to, Key: op.key, Weight: op.weight, Data: op.data})
+	case txRemoveEdge:
+		return g.removeEdgeLocked(op.from, op.to, op.key)
+	case txAddTypedEdge:
+		return g.addEdgeLocked(&Edge{
+			From:                  op.from,
+			To:                    op.to,
+			Key:                   typedEdgeKey(op.opts.Key, op.edgeKind),
+			Kind:                  op.edgeKind,
+			Weight:                op.weight,
+			Data:                  op.opts.Data,
+			CascadeToTarget:       op.opts.CascadeToTarget,
+			CascadeFromTarget:     op.opts.CascadeFromTarget,
+			CascadeLastToTarget:   op.opts.CascadeLastToTarget,
+			CascadeLastFromTarget: op.opts.CascadeLastFromTarget,
+		})
+	case txRemoveTypedEdge:
+		return g.removeEdgeLocked(op.from, op.to, op.key)
+	default:
+		return fmt.Errorf("unknown tx op")
+	}
+}
+
+// TxOpError describes one op within a batch that failed validation.
+type TxOpError struct {
+	// Index is the op's position in the batch, in the order it was
+	// buffered.
+	Index int
+	// Op is a human-readable rendering of the offending call, e.g.
+	// "AddEdge(a, b)".
+	Op string
+	// Reason explains why the op failed to apply.
+	Reason string
+}
+
+// TxError is returned by Tx.Commit when one or more buffered ops fail to
+// apply against the batch's working copy of the graph. It lists every
+// offending op, not just the first, since Commit tries the whole batch
+// before deciding whether to replace g's state with the result.
+type TxError struct {
+	Ops []TxOpError
+}
+
+func (e *TxError) Error() string {
+	msgs := make([]string, len(e.Ops))
+	for i, op := range e.Ops {
+		msgs[i] = fmt.Sprintf("%s: %s", op.Op, op.Reason)
+	}
+	return fmt.Sprintf("gropher: tx commit failed (%d op(s)): %s", len(e.Ops), strings.Join(msgs, "; "))
+}
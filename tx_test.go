@@ -0,0 +1,141 @@
+package gropher
+
+import "testing"
+
+func TestTxCommitAppliesBufferedOps(t *testing.T) {
+	g := New()
+	if err := g.AddNode("a", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := g.Begin()
+	tx.AddNode("b", nil).
+		AddNode("c", nil).
+		AddEdge("a", "b", 1).
+		AddTypedEdge("b", "c", "knows", 1, EdgeOptions{})
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+
+	if _, err := g.GetNode("b"); err != nil {
+		t.Errorf("Expected node b to exist after commit: %v", err)
+	}
+	if _, err := g.GetNode("c"); err != nil {
+		t.Errorf("Expected node c to exist after commit: %v", err)
+	}
+	if _, err := g.GetEdges("a", "b"); err != nil {
+		t.Errorf("Expected edge a->b to exist after commit: %v", err)
+	}
+	if edges := g.EdgesOfKind("knows"); len(edges) != 1 {
+		t.Errorf("Expected 1 knows edge after commit, got %d", len(edges))
+	}
+}
+
+func TestTxCommitRejectsPartiallyInvalidBatch(t *testing.T) {
+	g := New()
+	if err := g.AddNode("a", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := g.Begin()
+	tx.AddNode("b", nil).
+		AddEdge("a", "missing", 1).
+		AddNode("a", nil) // duplicate ID
+
+	err := tx.Commit()
+	if err == nil {
+		t.Fatal("Expected Commit to return an error for an invalid batch")
+	}
+
+	txErr, ok := err.(*TxError)
+	if !ok {
+		t.Fatalf("Expected a *TxError, got %T: %v", err, err)
+	}
+	if len(txErr.Ops) != 2 {
+		t.Fatalf("Expected 2 offending ops, got %d: %v", len(txErr.Ops), txErr.Ops)
+	}
+
+	if _, err := g.GetNode("b"); err == nil {
+		t.Error("Expected node b to NOT exist: a failed Commit must not apply any op")
+	}
+}
+
+func TestTxRollbackDiscardsBufferedOps(t *testing.T) {
+	g := New()
+	tx := g.Begin()
+	tx.AddNode("a", nil)
+	tx.Rollback()
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Expected Commit on an empty (rolled-back) Tx to succeed, got %v", err)
+	}
+	if _, err := g.GetNode("a"); err == nil {
+		t.Error("Expected rolled-back AddNode to never have been applied")
+	}
+}
+
+func TestTxCommitAccountsForCascadeDeletes(t *testing.T) {
+	g := New()
+	for _, id := range []string{"order", "lineItem"} {
+		if err := g.AddNode(id, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.AddTypedEdge("order", "lineItem", "contains", 1, EdgeOptions{CascadeToTarget: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := g.Begin()
+	tx.RemoveNode("order").
+		AddEdge("lineItem", "x", 1) // lineItem is cascade-deleted by the RemoveNode above
+
+	err := tx.Commit()
+	if err == nil {
+		t.Fatal("Expected Commit to fail: the AddEdge targets a node cascade-deleted earlier in the same batch")
+	}
+
+	txErr, ok := err.(*TxError)
+	if !ok {
+		t.Fatalf("Expected a *TxError, got %T: %v", err, err)
+	}
+	if len(txErr.Ops) != 1 || txErr.Ops[0].Index != 1 {
+		t.Fatalf("Expected the AddEdge at index 1 to be the sole offending op, got %v", txErr.Ops)
+	}
+
+	if _, err := g.GetNode("order"); err != nil {
+		t.Errorf("Expected a failed Commit to leave order untouched: %v", err)
+	}
+	if _, err := g.GetNode("lineItem"); err != nil {
+		t.Errorf("Expected a failed Commit to leave lineItem untouched: %v", err)
+	}
+}
+
+func TestTxSnapshotIsStableAndIndependent(t *testing.T) {
+	g := New()
+	if err := g.AddNode("a", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := g.Begin()
+	tx.AddNode("b", nil)
+
+	snap := tx.Snapshot()
+	if _, err := snap.GetNode("b"); err == nil {
+		t.Error("Expected Snapshot to exclude uncommitted ops")
+	}
+	if _, err := snap.GetNode("a"); err != nil {
+		t.Error("Expected Snapshot to include already-committed state")
+	}
+
+	if err := snap.AddNode("only-in-snapshot", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.GetNode("only-in-snapshot"); err == nil {
+		t.Error("Expected mutating the snapshot to not affect the live Graph")
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package gropher
+
+// EdgeOptions configures an edge added via AddTypedEdge: its parallel-edge
+// Key, arbitrary Data, and cascade-delete behavior tied to the edge's Kind.
+//
+// The four Cascade* flags mirror EliasDB's cascading relationships. The
+// plain variants always cascade when the triggering endpoint is removed via
+// RemoveNode; the Last* variants only cascade when the edge being removed
+// because of that is the last edge of its Kind between the same pair of
+// Nodes.
+type EdgeOptions struct {
+	Key  string
+	Data interface{}
+
+	// CascadeToTarget removes To whenever From is removed.
+	CascadeToTarget bool
+	// CascadeFromTarget removes From whenever To is removed.
+	CascadeFromTarget bool
+	// CascadeLastToTarget removes To when From is removed, but only if
+	// doing so removes the last edge of Kind between From and To.
+	CascadeLastToTarget bool
+	// CascadeLastFromTarget removes From when To is removed, but only if
+	// doing so removes the last edge of Kind between From and To.
+	CascadeLastFromTarget bool
+}
+
+// AddTypedEdge adds an edge labeled with kind between two Nodes, with
+// cascade-delete behavior configured via opts. Like AddEdgeKeyed, edges are
+// distinguished by opts.Key; adding an edge whose Key already exists
+// between the pair replaces it. If opts.Key is empty, it defaults to kind,
+// so two AddTypedEdge calls for the same pair with different kinds and no
+// explicit Key produce two parallel edges instead of the second silently
+// replacing the first; two calls with the same kind and no Key still
+// replace each other, same as before. RemoveTypedEdge must be passed the
+// same (defaulted or explicit) key to remove such an edge.
+func (g *Graph) AddTypedEdge(from, to, kind string, weight float64, opts EdgeOptions) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := typedEdgeKey(opts.Key, kind)
+
+	return g.addEdgeLocked(&Edge{
+		From:                  from,
+		To:                    to,
+		Key:                   key,
+		Kind:                  kind,
+		Weight:                weight,
+		Data:                  opts.Data,
+		CascadeToTarget:       opts.CascadeToTarget,
+		CascadeFromTarget:     opts.CascadeFromTarget,
+		CascadeLastToTarget:   opts.CascadeLastToTarget,
+		CascadeLastFromTarget: opts.CascadeLastFromTarget,
+	})
+}
+
+// typedEdgeKey resolves an AddTypedEdge call's effective parallel-edge key:
+// key itself when explicit, otherwise kind, so two different kinds added
+// with no explicit key don't collide into a single replaced edge.
+func typedEdgeKey(key, kind string) string {
+	if key == "" {
+		return kind
+	}
+	return key
+}
+
+// RemoveTypedEdge removes the edge identified by key between from and to.
+// It does not itself trigger cascades; cascading deletes only happen when a
+// node is removed via RemoveNode, per the removed edges' Cascade* flags.
+func (g *Graph) RemoveTypedEdge(from, to, key string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.removeEdgeLocked(from, to, key)
+}
+
+// EdgesOfKind returns every edge in the graph with the given Kind.
+func (g *Graph) EdgesOfKind(kind string) []*Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var edges []*Edge
+	for _, tos := range g.Edges {
+		for _, parallel := range tos {
+			for _, edge := range parallel {
+				if edge.Kind == kind {
+					copied := *edge
+					edges = append(edges, &copied)
+				}
+			}
+		}
+	}
+	return edges
+}
+
+// cascadeRemoveNodeLocked removes id and its Edges, then follows any
+// Cascade*-flagged Edges to remove further Nodes. visited guards against
+// cascade cycles, e.g. two Nodes cascading to each other. Callers must hold
+// g.mu for writing.
+func (g *Graph) cascadeRemoveNodeLocked(id string, visited map[string]bool) {
+	if visited[id] {
+		return
+	}
+	visited[id] = true
+	if _, exists := g.Nodes[id]; !exists {
+		return
+	}
+
+	var toCascade, fromCascade []string
+
+	for to, parallel := range g.Edges[id] {
+		lastOfKind := lastEdgeOfKind(parallel)
+		for _, edge := range parallel {
+			if edge.CascadeToTarget || (edge.CascadeLastToTarget && lastOfKind[edge.Kind]) {
+				toCascade = append(toCascade, to)
+			}
+		}
+	}
+	for from, edges := range g.Edges {
+		if from == id {
+			continue
+		}
+		parallel := edges[id]
+		lastOfKind := lastEdgeOfKind(parallel)
+		for _, edge := range parallel {
+			if edge.CascadeFromTarget || (edge.CascadeLastFromTarget && lastOfKind[edge.Kind]) {
+				fromCascade = append(fromCascade, from)
+			}
+		}
+	}
+
+	g.removeNodeLocked(id)
+
+	for _, target := range toCascade {
+		g.cascadeRemoveNodeLocked(target, visited)
+	}
+	for _, source := range fromCascade {
+		g.cascadeRemoveNodeLocked(source, visited)
+	}
+}
+
+// lastEdgeOfKind reports, for each Kind present in parallel, whether
+// exactly one edge of that Kind exists in it — i.e. whether removing it
+// would remove the last edge of that Kind between the pair.
+func lastEdgeOfKind(parallel []*Edge) map[string]bool {
+	counts := make(map[string]int, len(parallel))
+	for _, edge := range parallel {
+		counts[edge.Kind]++
+	}
+	last := make(map[string]bool, len(counts))
+	for kind, n := range counts {
+		last[kind] = n == 1
+	}
+	return last
+}
@@ -0,0 +1,205 @@
+package gropher
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAddTypedEdgeAndEdgesOfKind(t *testing.T) {
+	g := New()
+	for _, id := range []string{"alice", "bob", "acme"} {
+		if err := g.AddNode(id, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.AddTypedEdge("alice", "bob", "knows", 1, EdgeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedEdge("alice", "acme", "employedBy", 1, EdgeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedEdge("bob", "acme", "employedBy", 1, EdgeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	employs := g.EdgesOfKind("employedBy")
+	if len(employs) != 2 {
+		t.Fatalf("Expected 2 employedBy edges, got %d", len(employs))
+	}
+
+	if got := g.EdgesOfKind("nonexistent"); len(got) != 0 {
+		t.Errorf("Expected 0 edges for an unused kind, got %d", len(got))
+	}
+}
+
+func TestAddTypedEdgeDifferentKindsAreParallelByDefault(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+	g.AddNode("b", nil)
+
+	if err := g.AddTypedEdge("a", "b", "knows", 1, EdgeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedEdge("a", "b", "employs", 2, EdgeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	edges, err := g.GetEdges("a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edges) != 2 {
+		t.Fatalf("Expected 2 parallel edges of different kinds, got %d: %+v", len(edges), edges)
+	}
+
+	kinds := make(map[string]bool)
+	for _, e := range edges {
+		kinds[e.Kind] = true
+	}
+	if !kinds["knows"] || !kinds["employs"] {
+		t.Errorf("Expected both knows and employs edges to survive, got %+v", edges)
+	}
+}
+
+func TestRemoveTypedEdge(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+	g.AddNode("b", nil)
+	if err := g.AddTypedEdge("a", "b", "knows", 1, EdgeOptions{Key: "k1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.RemoveTypedEdge("a", "b", "k1"); err != nil {
+		t.Fatalf("RemoveTypedEdge returned error: %v", err)
+	}
+	if _, err := g.GetEdges("a", "b"); err == nil {
+		t.Error("Expected edge to be removed")
+	}
+
+	if err := g.RemoveTypedEdge("a", "b", "k1"); err == nil {
+		t.Error("Expected error removing an already-removed edge")
+	}
+}
+
+func TestRemoveNodeCascadeToTarget(t *testing.T) {
+	g := New()
+	for _, id := range []string{"order", "lineItem", "unrelated"} {
+		g.AddNode(id, nil)
+	}
+	// Deleting the order should cascade-delete its line item.
+	if err := g.AddTypedEdge("order", "lineItem", "contains", 1, EdgeOptions{CascadeToTarget: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge("order", "unrelated", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.RemoveNode("order"); err != nil {
+		t.Fatalf("RemoveNode returned error: %v", err)
+	}
+	if _, err := g.GetNode("lineItem"); err == nil {
+		t.Error("Expected lineItem to be cascade-deleted along with order")
+	}
+	if _, err := g.GetNode("unrelated"); err != nil {
+		t.Errorf("Expected unrelated node to survive: %v", err)
+	}
+}
+
+func TestRemoveNodeCascadeFromTarget(t *testing.T) {
+	g := New()
+	g.AddNode("author", nil)
+	g.AddNode("draftPost", nil)
+	// Deleting the post should cascade-delete its sole author.
+	if err := g.AddTypedEdge("author", "draftPost", "wrote", 1, EdgeOptions{CascadeFromTarget: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.RemoveNode("draftPost"); err != nil {
+		t.Fatalf("RemoveNode returned error: %v", err)
+	}
+	if _, err := g.GetNode("author"); err == nil {
+		t.Error("Expected author to be cascade-deleted along with draftPost")
+	}
+}
+
+func TestRemoveNodeCascadeLastToTarget(t *testing.T) {
+	g := New()
+	for _, id := range []string{"tag", "postA", "postB"} {
+		g.AddNode(id, nil)
+	}
+	if err := g.AddTypedEdge("tag", "postA", "tags", 1, EdgeOptions{Key: "postA", CascadeLastToTarget: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedEdge("tag", "postB", "tags", 1, EdgeOptions{Key: "postB", CascadeLastToTarget: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Direct Edge Removal Does Not Cascade", func(t *testing.T) {
+		if err := g.RemoveTypedEdge("tag", "postA", "postA"); err != nil {
+			t.Fatal(err)
+		}
+		// postA is untouched: cascades only fire from RemoveNode.
+		if _, err := g.GetNode("postA"); err != nil {
+			t.Errorf("Expected postA to survive a direct edge removal: %v", err)
+		}
+	})
+
+	t.Run("Cascades Last-Of-Kind Edge On Node Removal", func(t *testing.T) {
+		g2 := New()
+		for _, id := range []string{"tag", "post"} {
+			g2.AddNode(id, nil)
+		}
+		if err := g2.AddTypedEdge("tag", "post", "tags", 1, EdgeOptions{CascadeLastToTarget: true}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := g2.RemoveNode("tag"); err != nil {
+			t.Fatalf("RemoveNode returned error: %v", err)
+		}
+		if _, err := g2.GetNode("post"); err == nil {
+			t.Error("Expected post to be cascade-deleted as the last 'tags' edge was removed")
+		}
+	})
+}
+
+func TestRemoveNodeCascadeDoesNotLoopOnCycles(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+	g.AddNode("b", nil)
+	if err := g.AddTypedEdge("a", "b", "mutual", 1, EdgeOptions{CascadeToTarget: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTypedEdge("b", "a", "mutual", 1, EdgeOptions{CascadeToTarget: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.RemoveNode("a"); err != nil {
+		t.Fatalf("RemoveNode returned error: %v", err)
+	}
+
+	if _, err := g.GetNode("a"); err == nil {
+		t.Error("Expected a to be removed")
+	}
+	if _, err := g.GetNode("b"); err == nil {
+		t.Error("Expected b to be cascade-removed via the mutual cascade edge")
+	}
+}
+
+func TestSaveLoadFileIncludesSchemaVersion(t *testing.T) {
+	g := New()
+	g.AddNode("a", nil)
+	tempFile := "typed_edge_schema_version.json"
+	defer os.Remove(tempFile)
+
+	if err := g.SaveToFile(tempFile); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := New()
+	if err := loaded.LoadFromFile(tempFile); err != nil {
+		t.Fatalf("LoadFromFile returned error: %v", err)
+	}
+	if _, err := loaded.GetNode("a"); err != nil {
+		t.Errorf("Expected node a to survive the round trip: %v", err)
+	}
+}